@@ -0,0 +1,128 @@
+package chesseract
+
+// A boardIndex maps the squares on a board to the pieces on them, so that
+// looking up the piece at a position doesn't require scanning every piece.
+// The index is only valid for the Pieces slice it was created from.
+type boardIndex struct {
+	// base and n identify the Pieces slice this index belongs to
+	base *Piece
+	n    int
+
+	// squares contains the index of the piece on each square plus one, or 0
+	// if the square is empty
+	squares []uint8
+}
+
+// maxIndexedSquares is the size of the largest board that gets an index
+const maxIndexedSquares = 4096
+
+// denseIndex converts a position to an index in a dense array of squares, and
+// returns the size of that array. This only works for position types with a
+// fixed size.
+func denseIndex(pos Position) (index int, size int, ok bool) {
+	if p, ok := pos.(position2D); ok {
+		if p[0] < 0 || p[0] >= 8 || p[1] < 0 || p[1] >= 8 {
+			return 0, 0, false
+		}
+		return p[0] + 8*p[1], 64, true
+	} else if p, ok := pos.(position3D); ok {
+		for _, c := range p {
+			if c < 0 || c >= 5 {
+				return 0, 0, false
+			}
+		}
+		return p[0] + 5*p[1] + 25*p[2], 125, true
+	} else if p, ok := pos.(position4D); ok {
+		for _, c := range p {
+			if c < 0 || c >= 6 {
+				return 0, 0, false
+			}
+		}
+		return p[0] + 6*p[1] + 36*p[2] + 216*p[3], 1296, true
+	} else if p, ok := pos.(positionN); ok {
+		// Larger boards would make copying the index too expensive
+		index, size = 0, 1
+		for i := 0; i < p.dims; i++ {
+			if p.c[i] < 0 || p.c[i] >= p.size {
+				return 0, 0, false
+			}
+			index += size * p.c[i]
+			size *= p.size
+		}
+		if size > maxIndexedSquares {
+			return 0, 0, false
+		}
+		return index, size, true
+	}
+	return 0, 0, false
+}
+
+// newBoardIndex creates an index for a list of pieces, or returns nil if the
+// pieces' positions cannot be indexed
+func newBoardIndex(pieces []Piece) *boardIndex {
+	if len(pieces) == 0 || len(pieces) >= 0xff {
+		return nil
+	}
+
+	_, size, ok := denseIndex(pieces[0].Position)
+	if !ok {
+		return nil
+	}
+
+	rv := &boardIndex{
+		base:    &pieces[0],
+		n:       len(pieces),
+		squares: make([]uint8, size),
+	}
+	for i, p := range pieces {
+		j, sz, ok := denseIndex(p.Position)
+		if !ok || sz != size {
+			return nil
+		}
+		rv.squares[j] = uint8(i + 1)
+	}
+	return rv
+}
+
+// valid tests if this index belongs to this list of pieces
+func (idx *boardIndex) valid(pieces []Piece) bool {
+	return idx != nil && len(pieces) == idx.n && len(pieces) > 0 && &pieces[0] == idx.base
+}
+
+// lookup finds the index in Pieces of the piece at the specified position.
+// The second return value is false if the index cannot be used to answer
+// this question, in which case the caller should scan all pieces.
+func (idx *boardIndex) lookup(pieces []Piece, pos Position) (int, bool) {
+	if !idx.valid(pieces) {
+		return -1, false
+	}
+
+	j, size, ok := denseIndex(pos)
+	if !ok || size != len(idx.squares) {
+		return -1, false
+	}
+	return int(idx.squares[j]) - 1, true
+}
+
+// pieceIndex returns the index in Pieces of the piece at the specified
+// position, or -1 if the square is empty
+func (b Board) pieceIndex(pos Position) int {
+	if i, ok := b.index.lookup(b.Pieces, pos); ok {
+		return i
+	}
+
+	for i, p := range b.Pieces {
+		if p.Position.Equals(pos) {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexed returns a copy of this board that has an index, if possible
+func (b Board) indexed() Board {
+	if !b.index.valid(b.Pieces) {
+		b.index = newBoardIndex(b.Pieces)
+	}
+	return b
+}
@@ -0,0 +1,105 @@
+package chesseract
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// unindexed returns a copy of a board without an index, which forces At to
+// scan every piece
+func unindexed(b Board) Board {
+	b.index = nil
+	return b
+}
+
+func TestBoardIndex(t *testing.T) {
+	for _, rs := range []RuleSet{Boring2D{}, Chesseract{}} {
+		rng := rand.New(rand.NewSource(2))
+		board := rs.DefaultBoard()
+		for i := 0; i < 40; i++ {
+			moves := LegalMoves(rs, board)
+			if len(moves) == 0 {
+				break
+			}
+			var err error
+			board, err = rs.ApplyMove(board, moves[rng.Intn(len(moves))])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if board.index == nil {
+				t.Fatalf("%s: board has no index after moving", rs)
+			}
+
+			for _, pos := range rs.AllPositions() {
+				p, ok := board.At(pos)
+				q, qok := unindexed(board).At(pos)
+				if ok != qok || p != q {
+					t.Fatalf("%s: indexed lookup of %s returns %v, but expected %v", rs, pos, p, q)
+				}
+			}
+		}
+	}
+
+	// Changing the pieces invalidates the index
+	rs := Boring2D{}
+	board, err := rs.ApplyMove(rs.DefaultBoard(), Move{PAWN, position2D{4, 1}, position2D{4, 3}, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	board.Pieces = board.Pieces[:len(board.Pieces)-1]
+	if _, ok := board.At(position2D{4, 3}); ok {
+		t.Errorf("Lookup uses a stale index")
+	}
+}
+
+func benchmarkAt(b *testing.B, rs RuleSet, board Board) {
+	all := rs.AllPositions()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		board.At(all[i%len(all)])
+	}
+}
+
+func benchmarkApplyMove(b *testing.B, rs RuleSet, board Board, index bool) {
+	moves := LegalMoves(rs, board)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bb := board
+		if !index {
+			bb = unindexed(board)
+		}
+		rs.ApplyMove(bb, moves[i%len(moves)])
+	}
+}
+
+func BenchmarkAtLinear2D(b *testing.B) {
+	benchmarkAt(b, Boring2D{}, unindexed(Boring2D{}.DefaultBoard()))
+}
+
+func BenchmarkAtIndexed2D(b *testing.B) {
+	benchmarkAt(b, Boring2D{}, Boring2D{}.DefaultBoard().indexed())
+}
+
+func BenchmarkAtLinear4D(b *testing.B) {
+	benchmarkAt(b, Chesseract{}, unindexed(Chesseract{}.DefaultBoard()))
+}
+
+func BenchmarkAtIndexed4D(b *testing.B) {
+	benchmarkAt(b, Chesseract{}, Chesseract{}.DefaultBoard().indexed())
+}
+
+func BenchmarkApplyMoveLinear2D(b *testing.B) {
+	benchmarkApplyMove(b, Boring2D{}, Boring2D{}.DefaultBoard(), false)
+}
+
+func BenchmarkApplyMoveIndexed2D(b *testing.B) {
+	benchmarkApplyMove(b, Boring2D{}, Boring2D{}.DefaultBoard().indexed(), true)
+}
+
+func BenchmarkApplyMoveLinear4D(b *testing.B) {
+	benchmarkApplyMove(b, Chesseract{}, Chesseract{}.DefaultBoard(), false)
+}
+
+func BenchmarkApplyMoveIndexed4D(b *testing.B) {
+	benchmarkApplyMove(b, Chesseract{}, Chesseract{}.DefaultBoard().indexed(), true)
+}
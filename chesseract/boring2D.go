@@ -38,7 +38,10 @@ func (p position2D) WorldPosition() (x, y, z float32) {
 }
 
 // The Boring2D type implements the old 2D 8x8 board we're so used to by now
-type Boring2D struct{}
+type Boring2D struct {
+	// chess960 enables the Chess960 castling rules
+	chess960 bool
+}
 
 func (Boring2D) String() string {
 	return "Boring2D"
@@ -86,6 +89,12 @@ func (Boring2D) DefaultBoard() Board {
 			{ROOK, BLACK, position2D{7, 7}},
 		},
 		Turn: WHITE,
+		Castling: []Position{
+			position2D{0, 0},
+			position2D{7, 0},
+			position2D{0, 7},
+			position2D{7, 7},
+		},
 	}
 }
 
@@ -122,7 +131,7 @@ func (Boring2D) ParsePosition(s string) (Position, error) {
 
 // CanMove tests whether a piece can move to the specified new position on the board.
 // Note: this only tests movement rules; the check check is performed elsewhere.
-func (Boring2D) CanMove(board Board, piece Piece, pos Position) bool {
+func (rs Boring2D) CanMove(board Board, piece Piece, pos Position) bool {
 	var oldPos, newPos position2D
 	var ok bool
 	if oldPos, ok = piece.Position.(position2D); !ok {
@@ -143,6 +152,14 @@ func (Boring2D) CanMove(board Board, piece Piece, pos Position) bool {
 		return false
 	}
 
+	// In Chess960, castling is written as the king moving onto its own rook
+	if piece.PieceType == KING && rs.chess960 {
+		if op, ok := board.At(newPos); ok && op.Colour == piece.Colour && op.PieceType == ROOK {
+			_, _, ok := rs.castling960(board, piece, newPos)
+			return ok
+		}
+	}
+
 	capture := false
 
 	// You can't capture your own pieces
@@ -155,7 +172,11 @@ func (Boring2D) CanMove(board Board, piece Piece, pos Position) bool {
 	}
 
 	if piece.PieceType == KING {
-		// TODO: castling
+		// Castling moves the king two squares towards one of its rooks
+		if dx*dx == 4 && dy == 0 && !capture && !rs.chess960 {
+			_, ok := rs.castlingRook(board, piece, dx)
+			return ok
+		}
 
 		// Move one square in any direction
 		if dx*dx > 1 || dy*dy > 1 {
@@ -189,7 +210,7 @@ func (Boring2D) CanMove(board Board, piece Piece, pos Position) bool {
 			return false
 		}
 
-		if capture {
+		if capture || board.EnPassant.canCapture(board, piece.Colour, newPos) {
 			return dy*dy == 1 && dx*dx == 1
 		} else {
 			if dx != 0 {
@@ -223,6 +244,175 @@ func (Boring2D) CanMove(board Board, piece Piece, pos Position) bool {
 	return true
 }
 
+// LegalMoves returns all legal moves for the player whose turn it is
+func (rs Boring2D) LegalMoves(board Board) []Move {
+	return legalMoves(rs, board, func(p Piece) []Position {
+		return rs.targets(board, p)
+	})
+}
+
+var (
+	straight2D = []position2D{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+	diagonal2D = []position2D{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+	knight2D   = []position2D{{1, 2}, {2, 1}, {2, -1}, {1, -2}, {-1, -2}, {-2, -1}, {-2, 1}, {-1, 2}}
+	king2D     = []position2D{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, 1}, {1, -1}, {-1, 1}, {-1, -1}, {2, 0}, {-2, 0}}
+)
+
+// targets lists the positions a piece could possibly move to. The finer
+// points of the movement rules are left to CanMove.
+func (rs Boring2D) targets(board Board, piece Piece) []Position {
+	pos, ok := piece.Position.(position2D)
+	if !ok {
+		return nil
+	}
+
+	var rv []Position
+	onBoard := func(p position2D) bool {
+		return p[0] >= 0 && p[0] < 8 && p[1] >= 0 && p[1] < 8
+	}
+	jump := func(vectors []position2D) {
+		for _, v := range vectors {
+			p := position2D{pos[0] + v[0], pos[1] + v[1]}
+			if onBoard(p) {
+				rv = append(rv, p)
+			}
+		}
+	}
+	slide := func(vectors []position2D) {
+		for _, v := range vectors {
+			for p := (position2D{pos[0] + v[0], pos[1] + v[1]}); onBoard(p); p = (position2D{p[0] + v[0], p[1] + v[1]}) {
+				rv = append(rv, p)
+				if _, ok := board.At(p); ok {
+					break
+				}
+			}
+		}
+	}
+
+	if piece.PieceType == KING && rs.chess960 {
+		// Castling moves the king onto its own rook, which may be anywhere
+		// on the back rank
+		jump(king2D[:8])
+		for _, r := range board.Castling {
+			if r, ok := r.(position2D); ok && r[1] == pos[1] && (r[0]-pos[0])*(r[0]-pos[0]) > 1 {
+				rv = append(rv, r)
+			}
+		}
+	} else if piece.PieceType == KING {
+		jump(king2D)
+	} else if piece.PieceType == QUEEN {
+		slide(straight2D)
+		slide(diagonal2D)
+	} else if piece.PieceType == BISHOP {
+		slide(diagonal2D)
+	} else if piece.PieceType == KNIGHT {
+		jump(knight2D)
+	} else if piece.PieceType == ROOK {
+		slide(straight2D)
+	} else if piece.PieceType == PAWN {
+		dy := 1
+		if piece.Colour == BLACK {
+			dy = -1
+		}
+		jump([]position2D{{0, dy}, {0, 2 * dy}, {1, dy}, {-1, dy}})
+	}
+
+	return rv
+}
+
+// castlingRook finds the rook a king can castle with in the direction dx, if
+// castling is allowed at all.
+func (rs Boring2D) castlingRook(board Board, king Piece, dx int) (position2D, bool) {
+	kingPos, ok := king.Position.(position2D)
+	if !ok {
+		return position2D{}, false
+	}
+
+	for _, r := range board.Castling {
+		rookPos, ok := r.(position2D)
+		if !ok || rookPos[1] != kingPos[1] || (rookPos[0]-kingPos[0])*dx <= 0 {
+			continue
+		}
+		if rook, ok := board.At(rookPos); !ok || rook.PieceType != ROOK || rook.Colour != king.Colour {
+			continue
+		}
+
+		// All squares between the king and the rook have to be empty
+		vx, _, n := normalise2d(rookPos[0]-kingPos[0], 0)
+		for i := 1; i < n; i++ {
+			if _, ok := board.At(position2D{kingPos[0] + i*vx, kingPos[1]}); ok {
+				return position2D{}, false
+			}
+		}
+
+		// The king can't castle out of or through check. (Castling into
+		// check is caught by the check check.)
+		if isAttacked(rs, board, kingPos, king.Colour) || isAttacked(rs, board, position2D{kingPos[0] + vx, kingPos[1]}, king.Colour) {
+			return position2D{}, false
+		}
+
+		return rookPos, true
+	}
+
+	return position2D{}, false
+}
+
+// castling960 tests if a king can castle with the rook at the specified
+// position according to the Chess960 rules, and returns where both pieces end
+// up. As in regular chess, the king lands on the c or g file, and the rook
+// right next to it.
+func (rs Boring2D) castling960(board Board, king Piece, rookPos position2D) (kingDest, rookDest position2D, ok bool) {
+	kingPos, ok := king.Position.(position2D)
+	if !ok || rookPos[1] != kingPos[1] {
+		return position2D{}, position2D{}, false
+	}
+
+	allowed := false
+	for _, r := range board.Castling {
+		allowed = allowed || r.Equals(rookPos)
+	}
+	if rook, ok := board.At(rookPos); !allowed || !ok || rook.PieceType != ROOK || rook.Colour != king.Colour {
+		return position2D{}, position2D{}, false
+	}
+
+	y := kingPos[1]
+	if rookPos[0] > kingPos[0] {
+		kingDest, rookDest = position2D{6, y}, position2D{5, y}
+	} else {
+		kingDest, rookDest = position2D{2, y}, position2D{3, y}
+	}
+
+	// All squares the king and the rook pass over have to be empty, apart
+	// from the king and the rook themselves
+	lo, hi := kingPos[0], kingPos[0]
+	for _, x := range []int{rookPos[0], kingDest[0], rookDest[0]} {
+		if x < lo {
+			lo = x
+		}
+		if x > hi {
+			hi = x
+		}
+	}
+	for x := lo; x <= hi; x++ {
+		if x == kingPos[0] || x == rookPos[0] {
+			continue
+		}
+		if _, ok := board.At(position2D{x, y}); ok {
+			return position2D{}, position2D{}, false
+		}
+	}
+
+	// The king can't castle out of or through check
+	vx, _, n := normalise2d(kingDest[0]-kingPos[0], 0)
+	for i := 0; i <= n; i++ {
+		if isAttacked(rs, board, position2D{kingPos[0] + i*vx, y}, king.Colour) {
+			return position2D{}, position2D{}, false
+		}
+	}
+
+	return kingDest, rookDest, true
+}
+
 func normalise2d(dx, dy int) (vx, vy, r int) {
 	if dx < 0 {
 		vx = -1
@@ -253,18 +443,72 @@ func (rs Boring2D) ApplyMove(board Board, move Move) (Board, error) {
 		return Board{}, errIllegalMove
 	}
 
-	newBoard := board.movePiece(move)
+	newBoard := board
+	castled := false
+	if kingPos, ok := move.From.(position2D); ok && piece.PieceType == KING {
+		dx := move.To.(position2D)[0] - kingPos[0]
+		if rook, ok := board.At(move.To); ok && rs.chess960 && rook.Colour == piece.Colour {
+			// Chess960 castling: both pieces move to their regular castling
+			// squares, which may overlap with where they started
+			kingDest, rookDest, _ := rs.castling960(board, piece, move.To.(position2D))
+			newBoard = newBoard.removePiece(move.To)
+			newBoard = newBoard.movePiece(Move{KING, kingPos, kingDest, 0, 0})
+			newBoard = newBoard.placePiece(Piece{ROOK, piece.Colour, rookDest})
+
+			// The rook was gone by the time the king moved, so it still
+			// needs its castling rights revoked
+			var castling []Position
+			for _, r := range newBoard.Castling {
+				if p, ok := r.(position2D); !ok || p[1] != kingPos[1] {
+					castling = append(castling, r)
+				}
+			}
+			newBoard.Castling = castling
+			castled = true
+		} else if dx*dx == 4 {
+			// Castling: the rook jumps over the king
+			rookPos, _ := rs.castlingRook(board, piece, dx)
+			vx, _, _ := normalise2d(dx, 0)
+			newBoard = newBoard.movePiece(Move{ROOK, rookPos, position2D{kingPos[0] + vx, kingPos[1]}, 0, 0})
+			newBoard.HalfMoveClock = board.HalfMoveClock
+		}
+	}
+	if !castled {
+		newBoard = newBoard.movePiece(move)
+	}
 
-	// TODO: pawn promotion
-	// TODO: castling
+	if piece.PieceType == PAWN {
+		from, to := move.From.(position2D), move.To.(position2D)
+		if board.EnPassant.canCapture(board, piece.Colour, to) {
+			newBoard = newBoard.removePiece(board.EnPassant.Pawn)
+		} else if dy := to[1] - from[1]; dy*dy == 4 {
+			newBoard.EnPassant = EnPassant{
+				Target: position2D{from[0], from[1] + dy/2},
+				Pawn:   to,
+			}
+		}
+	}
 
-	// TODO: check if this results in the player being in check. Reject with errIllegalMove if it does.
+	// Pawns reaching the far end of the board get promoted
+	if piece.PieceType == PAWN && (move.To.(position2D)[1] == 0 || move.To.(position2D)[1] == 7) {
+		if !validPromotion(move.Promotion) {
+			return Board{}, errIllegalMove
+		}
+		newBoard = newBoard.promote(move.To, move.Promotion)
+	} else if move.Promotion != 0 {
+		return Board{}, errIllegalMove
+	}
 
-	if newBoard.Turn == BLACK {
-		newBoard.Turn = WHITE
-	} else {
-		newBoard.Turn = BLACK
+	if inCheck(rs, newBoard, piece.Colour) {
+		return Board{}, errIllegalMove
 	}
 
+	newBoard = newBoard.nextTurn(rs.PlayerColours())
+
 	return newBoard, nil
 }
+
+// Status determines whether the player whose turn it is is in check, checkmate, or stalemate
+func (rs Boring2D) Status(board Board) Status {
+	return gameStatus(rs, board)
+}
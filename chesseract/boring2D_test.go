@@ -316,7 +316,7 @@ func Test2DMatch(t *testing.T) {
 			dur = 0
 		}
 
-		move := Move{piece.PieceType, from, to, time.Duration(dur) * time.Millisecond}
+		move := Move{piece.PieceType, from, to, 0, time.Duration(dur) * time.Millisecond}
 		newBoard, err := rs.ApplyMove(match.Board, move)
 		if err != nil {
 			t.Logf("applying move '%s'-'%s': %v", m.From, m.To, err)
@@ -330,14 +330,317 @@ func Test2DMatch(t *testing.T) {
 
 	logMatch(t, match, nil)
 
-	_, err := rs.ApplyMove(match.Board, Move{QUEEN, position2D{0, 3}, position2D{3, 3}, 0})
+	_, err := rs.ApplyMove(match.Board, Move{QUEEN, position2D{0, 3}, position2D{3, 3}, 0, 0})
 	if err == nil {
 		t.Logf("This is not the Queen you were looking for")
 		t.Fail()
 	}
-	_, err = rs.ApplyMove(match.Board, Move{QUEEN, position2D{5, 2}, position2D{3, 3}, 0})
+	_, err = rs.ApplyMove(match.Board, Move{QUEEN, position2D{5, 2}, position2D{3, 3}, 0, 0})
 	if err == nil {
 		t.Logf("The Queen does not horse around")
 		t.Fail()
 	}
 }
+
+func TestCheckAndMate(t *testing.T) {
+	rs := Boring2D{}
+
+	type moov struct {
+		From, To string
+	}
+
+	// Fool's mate
+	moves := []moov{
+		{"f2", "f3"}, {"e7", "e5"},
+		{"g2", "g4"}, {"d8", "h4"},
+	}
+
+	match := Match{
+		RuleSet: rs,
+		Board:   rs.DefaultBoard(),
+	}
+	for _, m := range moves {
+		from, _ := rs.ParsePosition(m.From)
+		piece, _ := match.Board.At(from)
+		to, _ := rs.ParsePosition(m.To)
+
+		if st := rs.Status(match.Board); st != NORMAL {
+			t.Errorf("Unexpected status '%s' before %s-%s", st, m.From, m.To)
+		}
+
+		move := Move{piece.PieceType, from, to, 0, 0}
+		newBoard, err := rs.ApplyMove(match.Board, move)
+		if err != nil {
+			t.Errorf("applying move '%s'-'%s': %v", m.From, m.To, err)
+			return
+		}
+
+		match.Moves = append(match.Moves, move)
+		match.Board = newBoard
+	}
+
+	logMatch(t, match, nil)
+
+	if st := rs.Status(match.Board); st != CHECKMATE {
+		t.Errorf("Expected checkmate; got '%s'", st)
+	}
+	res := match.Result()
+	if len(res) != 2 || res[0] != 0 || res[1] != 1 {
+		t.Errorf("Expected black to win; got %v", res)
+	}
+
+	suite := []struct {
+		Board  Board
+		Status Status
+	}{
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{KING, BLACK, position2D{0, 7}},
+					{QUEEN, WHITE, position2D{1, 5}},
+					{KING, WHITE, position2D{2, 6}},
+				},
+				Turn: BLACK,
+			},
+			Status: STALEMATE,
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{KING, BLACK, position2D{0, 7}},
+					{QUEEN, WHITE, position2D{1, 5}},
+					{KING, WHITE, position2D{2, 6}},
+				},
+				Turn: WHITE,
+			},
+			Status: NORMAL,
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{KING, BLACK, position2D{0, 7}},
+					{ROOK, WHITE, position2D{7, 7}},
+					{KING, WHITE, position2D{4, 0}},
+				},
+				Turn: BLACK,
+			},
+			Status: CHECK,
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{KING, BLACK, position2D{0, 7}},
+					{ROOK, WHITE, position2D{7, 7}},
+					{ROOK, WHITE, position2D{7, 6}},
+					{KING, WHITE, position2D{4, 0}},
+				},
+				Turn: BLACK,
+			},
+			Status: CHECKMATE,
+		},
+	}
+
+	for _, tc := range suite {
+		st := rs.Status(tc.Board)
+		if st != tc.Status {
+			t.Errorf("Expected status '%s'; got '%s'", tc.Status, st)
+			logMatch(t, Match{RuleSet: rs, Board: tc.Board}, nil)
+		}
+	}
+}
+
+func TestSelfCheck(t *testing.T) {
+	rs := Boring2D{}
+	board := Board{
+		Pieces: []Piece{
+			{KING, WHITE, position2D{4, 0}},
+			{BISHOP, WHITE, position2D{4, 1}},
+			{ROOK, BLACK, position2D{4, 7}},
+			{KING, BLACK, position2D{0, 7}},
+		},
+		Turn: WHITE,
+	}
+
+	// The bishop is pinned
+	_, err := rs.ApplyMove(board, Move{BISHOP, position2D{4, 1}, position2D{5, 2}, 0, 0})
+	if err == nil {
+		t.Errorf("The bishop should not be able to leave the king exposed")
+	}
+
+	// The king can step aside, but not into the rook's line of fire
+	_, err = rs.ApplyMove(board, Move{KING, position2D{4, 0}, position2D{3, 1}, 0, 0})
+	if err != nil {
+		t.Errorf("The king should be able to step aside: %v", err)
+	}
+	board.Pieces[1].Position = position2D{5, 1}
+	_, err = rs.ApplyMove(board, Move{KING, position2D{4, 0}, position2D{4, 1}, 0, 0})
+	if err == nil {
+		t.Errorf("The king should not be able to move into check")
+	}
+}
+
+func TestCastling(t *testing.T) {
+	rs := Boring2D{}
+	board := Board{
+		Pieces: []Piece{
+			{KING, WHITE, position2D{4, 0}},
+			{ROOK, WHITE, position2D{0, 0}},
+			{ROOK, WHITE, position2D{7, 0}},
+			{KING, BLACK, position2D{4, 7}},
+			{ROOK, BLACK, position2D{0, 7}},
+			{BISHOP, BLACK, position2D{2, 4}},
+		},
+		Turn:     WHITE,
+		Castling: []Position{position2D{0, 0}, position2D{7, 0}, position2D{0, 7}},
+	}
+
+	// The bishop on c5 covers f2 and g1, so white can only castle queenside
+	if _, err := rs.ApplyMove(board, Move{KING, position2D{4, 0}, position2D{6, 0}, 0, 0}); err == nil {
+		t.Errorf("White shouldn't be able to castle into check")
+	}
+	newBoard, err := rs.ApplyMove(board, Move{KING, position2D{4, 0}, position2D{2, 0}, 0, 0})
+	if err != nil {
+		t.Fatalf("White should be able to castle queenside: %v", err)
+	}
+	if pc, ok := newBoard.At(position2D{3, 0}); !ok || pc.PieceType != ROOK {
+		t.Errorf("The rook should have moved to d1")
+	}
+	if _, ok := newBoard.At(position2D{0, 0}); ok {
+		t.Errorf("The rook should have left a1")
+	}
+	if len(newBoard.Castling) != 1 || !newBoard.Castling[0].Equals(position2D{0, 7}) {
+		t.Errorf("White should have lost all castling rights; got %v", newBoard.Castling)
+	}
+	logMatch(t, Match{RuleSet: rs, Board: newBoard}, nil)
+
+	// Black has no right to castle kingside, and can't castle through the bishop
+	if _, err := rs.ApplyMove(newBoard, Move{KING, position2D{4, 7}, position2D{6, 7}, 0, 0}); err == nil {
+		t.Errorf("Black has no rook to castle with on the kingside")
+	}
+	newBoard.Pieces = append(newBoard.Pieces, Piece{BISHOP, BLACK, position2D{1, 7}})
+	if _, err := rs.ApplyMove(newBoard, Move{KING, position2D{4, 7}, position2D{2, 7}, 0, 0}); err == nil {
+		t.Errorf("Black can't castle with a piece in the way")
+	}
+
+	// Moving the rook forfeits castling, even if it moves back
+	board.Pieces = board.Pieces[:5]
+	newBoard, err = rs.ApplyMove(board, Move{ROOK, position2D{7, 0}, position2D{7, 3}, 0, 0})
+	if err != nil {
+		t.Fatalf("error moving rook: %v", err)
+	}
+	newBoard.Turn = WHITE
+	newBoard, err = rs.ApplyMove(newBoard, Move{ROOK, position2D{7, 3}, position2D{7, 0}, 0, 0})
+	if err != nil {
+		t.Fatalf("error moving rook: %v", err)
+	}
+	if len(newBoard.Castling) != 2 {
+		t.Errorf("White should have lost one castling right; got %v", newBoard.Castling)
+	}
+	newBoard.Turn = WHITE
+	if _, err := rs.ApplyMove(newBoard, Move{KING, position2D{4, 0}, position2D{6, 0}, 0, 0}); err == nil {
+		t.Errorf("White shouldn't be able to castle with a moved rook")
+	}
+	if _, err := rs.ApplyMove(newBoard, Move{KING, position2D{4, 0}, position2D{2, 0}, 0, 0}); err != nil {
+		t.Errorf("White should still be able to castle queenside: %v", err)
+	}
+}
+
+func TestEnPassant(t *testing.T) {
+	rs := Boring2D{}
+
+	type moov struct {
+		From, To string
+	}
+	moves := []moov{
+		{"e2", "e4"}, {"a7", "a6"},
+		{"e4", "e5"}, {"d7", "d5"},
+		{"e5", "d6"},
+	}
+
+	match := Match{
+		RuleSet: rs,
+		Board:   rs.DefaultBoard(),
+	}
+	for _, m := range moves {
+		from, _ := rs.ParsePosition(m.From)
+		piece, _ := match.Board.At(from)
+		to, _ := rs.ParsePosition(m.To)
+
+		move := Move{piece.PieceType, from, to, 0, 0}
+		newBoard, err := rs.ApplyMove(match.Board, move)
+		if err != nil {
+			t.Fatalf("applying move '%s'-'%s': %v", m.From, m.To, err)
+		}
+
+		match.Moves = append(match.Moves, move)
+		match.Board = newBoard
+	}
+
+	logMatch(t, match, nil)
+
+	if _, ok := match.Board.At(position2D{3, 4}); ok {
+		t.Errorf("The pawn on d5 should have been captured")
+	}
+	if len(match.Board.Pieces) != 31 {
+		t.Errorf("Expected 31 pieces on the board; got %d", len(match.Board.Pieces))
+	}
+
+	// The opportunity to capture en passant only lasts one move
+	board := rs.DefaultBoard()
+	for _, m := range []Move{
+		{PAWN, position2D{4, 1}, position2D{4, 3}, 0, 0},
+		{PAWN, position2D{0, 6}, position2D{0, 5}, 0, 0},
+		{PAWN, position2D{4, 3}, position2D{4, 4}, 0, 0},
+		{PAWN, position2D{3, 6}, position2D{3, 4}, 0, 0},
+		{PAWN, position2D{0, 1}, position2D{0, 2}, 0, 0},
+		{PAWN, position2D{0, 5}, position2D{0, 4}, 0, 0},
+	} {
+		var err error
+		board, err = rs.ApplyMove(board, m)
+		if err != nil {
+			t.Fatalf("applying move '%s': %v", m, err)
+		}
+	}
+	if _, err := rs.ApplyMove(board, Move{PAWN, position2D{4, 4}, position2D{3, 5}, 0, 0}); err == nil {
+		t.Errorf("White waited too long to capture en passant")
+	}
+}
+
+func TestPromotion(t *testing.T) {
+	rs := Boring2D{}
+	board := Board{
+		Pieces: []Piece{
+			{KING, WHITE, position2D{4, 0}},
+			{PAWN, WHITE, position2D{0, 6}},
+			{PAWN, WHITE, position2D{3, 5}},
+			{KING, BLACK, position2D{7, 7}},
+		},
+		Turn: WHITE,
+	}
+
+	suite := []struct {
+		Move  Move
+		Legal bool
+	}{
+		{Move{PAWN, position2D{0, 6}, position2D{0, 7}, 0, 0}, false},
+		{Move{PAWN, position2D{0, 6}, position2D{0, 7}, KING, 0}, false},
+		{Move{PAWN, position2D{0, 6}, position2D{0, 7}, PAWN, 0}, false},
+		{Move{PAWN, position2D{0, 6}, position2D{0, 7}, KNIGHT, 0}, true},
+		{Move{PAWN, position2D{0, 6}, position2D{0, 7}, QUEEN, 0}, true},
+		{Move{PAWN, position2D{3, 5}, position2D{3, 6}, QUEEN, 0}, false},
+		{Move{PAWN, position2D{3, 5}, position2D{3, 6}, 0, 0}, true},
+	}
+
+	for _, tc := range suite {
+		newBoard, err := rs.ApplyMove(board, tc.Move)
+		if tc.Legal && err != nil {
+			t.Errorf("Move %s should be legal: %v", tc.Move, err)
+		} else if !tc.Legal && err == nil {
+			t.Errorf("Move %s should not be legal", tc.Move)
+		} else if tc.Legal && tc.Move.Promotion != 0 {
+			if pc, _ := newBoard.At(tc.Move.To); pc.PieceType != tc.Move.Promotion {
+				t.Errorf("Pawn turned into %s after %s", pc.PieceType, tc.Move)
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package chesseract
+
+// inCheck tests whether the king of the specified colour is under attack
+func inCheck(rs RuleSet, board Board, colour Colour) bool {
+	for _, king := range board.Pieces {
+		if king.PieceType != KING || king.Colour != colour {
+			continue
+		}
+		if attackedBy(rs, board, king.Position, colour) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAttacked tests whether a piece of the specified colour would be under
+// attack if it were at this position
+func isAttacked(rs RuleSet, board Board, pos Position, colour Colour) bool {
+	// Put a stand-in piece on the position, so pawns see something to capture
+	b := Board{
+		Pieces: make([]Piece, 0, len(board.Pieces)+1),
+		Turn:   board.Turn,
+	}
+	for _, p := range board.Pieces {
+		if !p.Position.Equals(pos) {
+			b.Pieces = append(b.Pieces, p)
+		}
+	}
+	b.Pieces = append(b.Pieces, Piece{PAWN, colour, pos})
+	b.index = newBoardIndex(b.Pieces)
+
+	return attackedBy(rs, b, pos, colour)
+}
+
+// attackedBy tests if any opponent of the specified colour can capture the piece at this position
+func attackedBy(rs RuleSet, board Board, pos Position, colour Colour) bool {
+	for _, p := range board.Pieces {
+		if p.Colour != colour && rs.CanMove(board, p, pos) {
+			return true
+		}
+	}
+	return false
+}
+
+// gameStatus determines the Status of a board for the player whose turn it is
+func gameStatus(rs RuleSet, board Board) Status {
+	check := inCheck(rs, board, board.Turn)
+	if len(LegalMoves(rs, board)) > 0 {
+		if check {
+			return CHECK
+		}
+		return NORMAL
+	}
+
+	if check {
+		return CHECKMATE
+	}
+	return STALEMATE
+}
@@ -0,0 +1,163 @@
+package chesseract
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+func init() {
+	RegisterRuleSet("Chess960", func() RuleSet {
+		return Chess960FromSeed(time.Now().UnixNano())
+	})
+	RegisterRuleSetFamily("Chess960", func(param string) RuleSet {
+		n, err := strconv.Atoi(param)
+		if err != nil || n < 0 || n >= 960 {
+			return nil
+		}
+		return NewChess960(n)
+	})
+}
+
+// The Chess960 type implements Fischer random chess: the old 2D board, with
+// the pieces on the back rank shuffled.
+//
+// In Chess960, castling is performed by moving the king onto its own rook.
+// Afterwards, the king and rook end up on the same squares as they would in
+// regular chess.
+type Chess960 struct {
+	// Setup is the number of the starting position, from 0 to 959. Number 518
+	// is the regular chess setup.
+	Setup int
+}
+
+// NewChess960 creates a Chess960 rule set with the specified starting position
+func NewChess960(setup int) Chess960 {
+	return Chess960{Setup: setup}
+}
+
+// Chess960FromSeed creates a Chess960 rule set with a starting position
+// picked at random using the seed
+func Chess960FromSeed(seed int64) Chess960 {
+	return NewChess960(rand.New(rand.NewSource(seed)).Intn(960))
+}
+
+// rules returns the underlying 2D rule set
+func (Chess960) rules() Boring2D {
+	return Boring2D{chess960: true}
+}
+
+func (rs Chess960) String() string {
+	return fmt.Sprintf("Chess960:%d", rs.Setup)
+}
+
+func (rs Chess960) PlayerColours() []Colour {
+	return rs.rules().PlayerColours()
+}
+
+// chess960Knights lists the ways of placing the two knights on the five
+// squares that are left after placing the bishops and the queen
+var chess960Knights = [10][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}
+
+// backRank returns the piece types on the back rank of the starting
+// position, from the a file to the h file. The setup number is decoded using
+// Scharnagl's numbering scheme.
+func (rs Chess960) backRank() [8]PieceType {
+	var rv [8]PieceType
+	n := rs.Setup % 960
+
+	// Place a piece on the ith empty square
+	place := func(i int, pt PieceType) {
+		for x := range rv {
+			if rv[x] != 0 {
+				continue
+			}
+			if i == 0 {
+				rv[x] = pt
+				return
+			}
+			i--
+		}
+	}
+
+	// One bishop on a light square, and the other on a dark square
+	rv[2*(n%4)+1] = BISHOP
+	n /= 4
+	rv[2*(n%4)] = BISHOP
+	n /= 4
+
+	place(n%6, QUEEN)
+	n /= 6
+
+	// Place the second knight first, so the first knight's index is unaffected
+	knights := chess960Knights[n]
+	place(knights[1], KNIGHT)
+	place(knights[0], KNIGHT)
+
+	// The king ends up in between the rooks
+	place(0, ROOK)
+	place(0, KING)
+	place(0, ROOK)
+
+	return rv
+}
+
+// DefaultBoard sets up the initial board configuration
+func (rs Chess960) DefaultBoard() Board {
+	rv := Board{
+		Turn: WHITE,
+	}
+
+	backRank := rs.backRank()
+	for _, c := range []struct {
+		Colour         Colour
+		BackRank, Pawn int
+	}{
+		{WHITE, 0, 1},
+		{BLACK, 7, 6},
+	} {
+		for x, pt := range backRank {
+			rv.Pieces = append(rv.Pieces, Piece{pt, c.Colour, position2D{x, c.BackRank}})
+			if pt == ROOK {
+				rv.Castling = append(rv.Castling, position2D{x, c.BackRank})
+			}
+		}
+		for x := 0; x < 8; x++ {
+			rv.Pieces = append(rv.Pieces, Piece{PAWN, c.Colour, position2D{x, c.Pawn}})
+		}
+	}
+
+	return rv
+}
+
+// AllPositions returns an iterator that allows one to range over all possible positions on the board in this variant
+func (rs Chess960) AllPositions() []Position {
+	return rs.rules().AllPositions()
+}
+
+// ParsePosition converts a string representation into a Position of the correct type
+func (rs Chess960) ParsePosition(s string) (Position, error) {
+	return rs.rules().ParsePosition(s)
+}
+
+// CanMove tests whether a piece can move to the specified new position on the board.
+// Note: this only tests movement rules; the check check is performed elsewhere.
+func (rs Chess960) CanMove(board Board, piece Piece, pos Position) bool {
+	return rs.rules().CanMove(board, piece, pos)
+}
+
+// LegalMoves returns all legal moves for the player whose turn it is
+func (rs Chess960) LegalMoves(board Board) []Move {
+	return rs.rules().LegalMoves(board)
+}
+
+// ApplyMove performs a move on the board, and returns the resulting board
+func (rs Chess960) ApplyMove(board Board, move Move) (Board, error) {
+	return rs.rules().ApplyMove(board, move)
+}
+
+// Status determines whether the player whose turn it is is in check, checkmate, or stalemate
+func (rs Chess960) Status(board Board) Status {
+	return rs.rules().Status(board)
+}
@@ -0,0 +1,170 @@
+package chesseract
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChess960Setups(t *testing.T) {
+	if s := NewChess960(518).DefaultBoard().FEN(); s != "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1" {
+		t.Errorf("Setup 518 is '%s'", s)
+	}
+	if s := NewChess960(0).DefaultBoard().FEN(); s != "bbqnnrkr/pppppppp/8/8/8/8/PPPPPPPP/BBQNNRKR w KQkq - 0 1" {
+		t.Errorf("Setup 0 is '%s'", s)
+	}
+
+	seen := make(map[[8]PieceType]bool)
+	for n := 0; n < 960; n++ {
+		rank := NewChess960(n).backRank()
+		if seen[rank] {
+			t.Errorf("Setup %d is a duplicate: %v", n, rank)
+		}
+		seen[rank] = true
+
+		var bishops []int
+		var rooksAndKing []PieceType
+		for x, pt := range rank {
+			if pt == BISHOP {
+				bishops = append(bishops, x)
+			} else if pt == ROOK || pt == KING {
+				rooksAndKing = append(rooksAndKing, pt)
+			}
+		}
+		if len(bishops) != 2 || (bishops[0]+bishops[1])%2 == 0 {
+			t.Errorf("Setup %d has its bishops on the wrong squares: %v", n, rank)
+		}
+		if len(rooksAndKing) != 3 || rooksAndKing[1] != KING {
+			t.Errorf("Setup %d has its king outside of its rooks: %v", n, rank)
+		}
+	}
+
+	if a, b := Chess960FromSeed(42), Chess960FromSeed(42); a != b {
+		t.Errorf("Seed 42 gives both %s and %s", a, b)
+	}
+}
+
+func TestChess960Castling(t *testing.T) {
+	rs := NewChess960(0)
+
+	// White king on g1, rooks on f1 and h1
+	board, err := ParseFEN("bbqnnrkr/pppppppp/8/8/8/8/PPPPPPPP/BBQNNRKR w KQkq - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(LegalMoves(rs, board)); n != 20 {
+		t.Errorf("%d legal moves in the starting position", n)
+	}
+
+	// Clear the way for queenside castling, where the king moves four squares
+	board, _ = ParseFEN("bbqnnrkr/pppppppp/8/8/8/8/PPPPPPPP/5RKR w KQkq - 0 1")
+	kingside := Move{KING, position2D{6, 0}, position2D{7, 0}, 0, 0}
+	queenside := Move{KING, position2D{6, 0}, position2D{5, 0}, 0, 0}
+
+	if s := SAN(rs, board, queenside); s != "O-O-O" {
+		t.Errorf("Queenside castling is written as '%s'", s)
+	}
+	b, err := rs.ApplyMove(board, queenside)
+	if err != nil {
+		t.Fatalf("Can't castle queenside: %v", err)
+	}
+	if s := b.FEN(); s != "bbqnnrkr/pppppppp/8/8/8/8/PPPPPPPP/2KR3R b kq - 1 1" {
+		t.Errorf("After castling queenside, board is '%s'", s)
+	}
+
+	// Kingside castling leaves the king where it is
+	board, _ = ParseFEN("bbqnnrkr/pppppppp/8/8/8/8/PPPPPPPP/6KR w Kkq - 0 1")
+	if s := SAN(rs, board, kingside); s != "O-O" {
+		t.Errorf("Kingside castling is written as '%s'", s)
+	}
+	b, err = rs.ApplyMove(board, kingside)
+	if err != nil {
+		t.Fatalf("Can't castle kingside: %v", err)
+	}
+	if s := b.FEN(); s != "bbqnnrkr/pppppppp/8/8/8/8/PPPPPPPP/5RK1 b kq - 1 1" {
+		t.Errorf("After castling kingside, board is '%s'", s)
+	}
+
+	// Regular chess castling is just an illegal king move
+	if _, err := rs.ApplyMove(board, Move{KING, position2D{6, 0}, position2D{4, 0}, 0, 0}); err == nil {
+		t.Errorf("King can jump two squares")
+	}
+
+	// The king can't castle through check
+	board, _ = ParseFEN("bbqnnrkr/pppppppp/8/8/8/8/PPPrPPPP/5RKR w KQkq - 0 1")
+	if _, err := rs.ApplyMove(board, queenside); err == nil {
+		t.Errorf("King can castle through check")
+	}
+}
+
+func TestChess960Perft(t *testing.T) {
+	type testCase struct {
+		FEN      string
+		Expected []int
+	}
+	cases := []testCase{
+		{
+			FEN:      "bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w HFhf - 2 9",
+			Expected: []int{21, 528, 12189},
+		},
+		{
+			FEN:      "2nnrbkr/p1qppppp/8/1ppb4/6PP/3PP3/PPP2P2/BQNNRBKR w HEhe - 1 9",
+			Expected: []int{21, 807, 18002},
+		},
+		{
+			FEN:      "b1q1rrkb/pppppppp/3nn3/8/P7/1PPP4/4PPPP/BQNNRKRB w GE - 1 9",
+			Expected: []int{20, 479, 10471},
+		},
+		{
+			FEN:      "qbbnnrkr/2pp2pp/p7/1p2pp2/8/P3PP2/1PPP1KPP/QBBNNR1R w hf - 0 9",
+			Expected: []int{22, 593, 13440},
+		},
+	}
+
+	rs := NewChess960(0)
+	for _, tc := range cases {
+		board, err := ParseFEN(tc.FEN)
+		if err != nil {
+			t.Errorf("%v", err)
+			continue
+		}
+		for i, expected := range tc.Expected {
+			if n := Perft(rs, board, i+1); n != expected {
+				t.Errorf("Perft(%d) of '%s' is %d; expected %d", i+1, tc.FEN, n, expected)
+			}
+		}
+	}
+}
+
+func TestMarshalChess960(t *testing.T) {
+	rs := GetRuleSet("Chess960:123")
+	if rs == nil {
+		t.Fatal("Chess960:123 is not a rule set")
+	}
+	if GetRuleSet("Chess960:960") != nil || GetRuleSet("Chess960:foo") != nil {
+		t.Errorf("Invalid setups are accepted")
+	}
+	if GetRuleSet("Chess960") == nil {
+		t.Errorf("Random setups are not available")
+	}
+
+	match := Match{
+		RuleSet: rs,
+		Board:   rs.DefaultBoard(),
+	}
+	buf, err := json.Marshal(match)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decodedMatch Match
+	err = json.Unmarshal(buf, &decodedMatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decodedMatch.RuleSet != rs {
+		t.Errorf("Rule set %s turns into %s", rs, decodedMatch.RuleSet)
+	}
+	if a, b := match.InitialBoard().FEN(), decodedMatch.InitialBoard().FEN(); a != b {
+		t.Errorf("Starting position '%s' turns into '%s'", a, b)
+	}
+}
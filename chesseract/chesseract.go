@@ -3,6 +3,7 @@ package chesseract
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -18,6 +19,9 @@ type Colour int8
 // The PieceType represents the type of a chesspiece
 type PieceType int8
 
+// The Status describes the state of a game from the perspective of the player whose turn it is
+type Status int8
+
 // A Position abstracts a position on a chess board
 type Position interface {
 	fmt.Stringer
@@ -61,14 +65,64 @@ type Board struct {
 
 	// Turn contains the colour of the player that makes the next move
 	Turn Colour
+
+	// Castling contains the positions of all rooks that can still be used for
+	// castling. That is: neither the rook nor its king have moved yet.
+	Castling []Position
+
+	// EnPassant records the pawn that advanced two squares in the previous
+	// move, if any
+	EnPassant EnPassant
+
+	// HalfMoveClock counts the number of moves since the last capture or pawn advance
+	HalfMoveClock int
+
+	// FullMoves counts the number of completed rounds, in which every player
+	// has made a move
+	FullMoves int
+
+	// Reserve contains the pieces each player has captured in variants that
+	// allow dropping them back onto the board. Pieces in the reserve have no
+	// Position.
+	Reserve []Piece
+
+	// Partial is set if this board only contains the part of the position
+	// that is visible to some of the players
+	Partial bool
+
+	// pieceHash contains the Zobrist hash of all pieces, or 0 if it hasn't
+	// been calculated yet
+	pieceHash uint64
+
+	// index maps squares to pieces, if available
+	index *boardIndex
+}
+
+// The EnPassant struct records a pawn that has just advanced two squares, and
+// can therefore be captured en passant
+type EnPassant struct {
+	// Target is the square the pawn skipped over. A pawn capturing en passant
+	// moves here.
+	Target Position
+
+	// Pawn is the current position of the pawn that can be captured
+	Pawn Position
+}
+
+// canCapture tests if a pawn of the specified colour can capture en passant
+// by moving to this position
+func (e EnPassant) canCapture(b Board, colour Colour, pos Position) bool {
+	if e.Target == nil || e.Pawn == nil || !e.Target.Equals(pos) {
+		return false
+	}
+	pc, ok := b.At(e.Pawn)
+	return ok && pc.PieceType == PAWN && pc.Colour != colour
 }
 
 // At returns the piece at the specified position, if it exists
 func (b Board) At(pos Position) (Piece, bool) {
-	for _, p := range b.Pieces {
-		if p.Position.Equals(pos) {
-			return p, true
-		}
+	if i := b.pieceIndex(pos); i >= 0 {
+		return b.Pieces[i], true
 	}
 	return Piece{}, false
 }
@@ -77,41 +131,230 @@ func (b Board) At(pos Position) (Piece, bool) {
 // The last piece to have moved is always at the end of the Pieces list
 func (b Board) movePiece(move Move) Board {
 	rv := Board{
-		Pieces: make([]Piece, 0, len(b.Pieces)),
-		Turn:   b.Turn,
+		Pieces:        make([]Piece, 0, len(b.Pieces)),
+		Turn:          b.Turn,
+		HalfMoveClock: b.HalfMoveClock + 1,
+		FullMoves:     b.FullMoves,
+		Reserve:       b.Reserve,
+		Partial:       b.Partial,
+	}
+	from, to := b.pieceIndex(move.From), b.pieceIndex(move.To)
+	var oldPiece Piece
+	ok := from >= 0
+	if ok {
+		oldPiece = b.Pieces[from]
 	}
-	oldPiece, ok := b.At(move.From)
 
-	for _, p := range b.Pieces {
-		if !p.Position.Equals(move.From) && !p.Position.Equals(move.To) {
+	// Captures and pawn advances reset the clock
+	capture := to >= 0 && to != from
+	if capture || (ok && oldPiece.PieceType == PAWN) {
+		rv.HalfMoveClock = 0
+	}
+
+	rv.pieceHash = b.piecesHash()
+	if capture {
+		rv.pieceHash ^= zobristPiece(b.Pieces[to])
+	}
+
+	// Moving a king or a rook (or capturing a rook) forfeits the right to castle with it
+	for _, r := range b.Castling {
+		if r.Equals(move.From) || r.Equals(move.To) {
+			continue
+		}
+		if ok && oldPiece.PieceType == KING {
+			if rook, rok := b.At(r); rok && rook.Colour == oldPiece.Colour {
+				continue
+			}
+		}
+		rv.Castling = append(rv.Castling, r)
+	}
+
+	for i, p := range b.Pieces {
+		if i != from && i != to {
 			rv.Pieces = append(rv.Pieces, p)
 		}
 	}
 	if ok {
+		rv.pieceHash ^= zobristPiece(oldPiece)
 		oldPiece.Position = move.To
+		rv.pieceHash ^= zobristPiece(oldPiece)
 		rv.Pieces = append(rv.Pieces, oldPiece)
 	}
+	rv.index = newBoardIndex(rv.Pieces)
 	return rv
 }
 
+// removePiece removes the piece at the specified position from the board
+func (b Board) removePiece(pos Position) Board {
+	rv := b
+	rv.Pieces = make([]Piece, 0, len(b.Pieces))
+	rv.pieceHash = b.piecesHash()
+	removed := b.pieceIndex(pos)
+	for i, p := range b.Pieces {
+		if i != removed {
+			rv.Pieces = append(rv.Pieces, p)
+		} else {
+			rv.pieceHash ^= zobristPiece(p)
+		}
+	}
+	rv.index = newBoardIndex(rv.Pieces)
+	return rv
+}
+
+// removeColour removes all pieces of one player from the board
+func (b Board) removeColour(c Colour) Board {
+	rv := b
+	rv.Pieces = make([]Piece, 0, len(b.Pieces))
+	rv.pieceHash = b.piecesHash()
+	for _, p := range b.Pieces {
+		if p.Colour != c {
+			rv.Pieces = append(rv.Pieces, p)
+		} else {
+			rv.pieceHash ^= zobristPiece(p)
+		}
+	}
+	rv.index = newBoardIndex(rv.Pieces)
+	return rv
+}
+
+// placePiece puts a piece on an empty square
+func (b Board) placePiece(p Piece) Board {
+	rv := b
+	rv.Pieces = make([]Piece, 0, len(b.Pieces)+1)
+	rv.Pieces = append(rv.Pieces, b.Pieces...)
+	rv.Pieces = append(rv.Pieces, p)
+	rv.pieceHash = b.piecesHash() ^ zobristPiece(p)
+	rv.index = newBoardIndex(rv.Pieces)
+	return rv
+}
+
+// addToReserve adds a piece to a player's reserve
+func (b Board) addToReserve(c Colour, pt PieceType) Board {
+	rv := b
+	rv.Reserve = make([]Piece, 0, len(b.Reserve)+1)
+	rv.Reserve = append(rv.Reserve, b.Reserve...)
+	rv.Reserve = append(rv.Reserve, Piece{PieceType: pt, Colour: c})
+	return rv
+}
+
+// takeFromReserve removes a piece from a player's reserve, if they have one
+func (b Board) takeFromReserve(c Colour, pt PieceType) (Board, bool) {
+	for i, p := range b.Reserve {
+		if p.Colour == c && p.PieceType == pt {
+			rv := b
+			rv.Reserve = make([]Piece, 0, len(b.Reserve)-1)
+			rv.Reserve = append(rv.Reserve, b.Reserve[:i]...)
+			rv.Reserve = append(rv.Reserve, b.Reserve[i+1:]...)
+			return rv, true
+		}
+	}
+	return b, false
+}
+
+// hasPieces tests if a player has any pieces left on the board
+func (b Board) hasPieces(c Colour) bool {
+	for _, p := range b.Pieces {
+		if p.Colour == c {
+			return true
+		}
+	}
+	return false
+}
+
+// hasKing tests if a player still has a king on the board
+func (b Board) hasKing(c Colour) bool {
+	for _, p := range b.Pieces {
+		if p.Colour == c && p.PieceType == KING {
+			return true
+		}
+	}
+	return false
+}
+
+// nextTurn passes the turn to the next player in line that still has pieces on
+// the board. The full move counter increases whenever the turn passes the
+// first player.
+func (b Board) nextTurn(colours []Colour) Board {
+	current := 0
+	for i, c := range colours {
+		if c == b.Turn {
+			current = i
+		}
+	}
+
+	for i := 1; i <= len(colours); i++ {
+		next := (current + i) % len(colours)
+		if next == 0 {
+			b.FullMoves++
+		}
+		b.Turn = colours[next]
+		if b.hasPieces(b.Turn) {
+			break
+		}
+	}
+	return b
+}
+
 // A Move wraps a single chess move
 type Move struct {
 	// PieceType contains the chess piece type that's moving
 	PieceType PieceType
 
-	// From contains the position where the chess piece started
+	// From contains the position where the chess piece started, or nil if
+	// the piece is dropped onto the board from the player's reserve
 	From Position
 
-	// To is the position it moved
+	// To is the position it moved, or nil if the move is hidden
 	To Position
 
+	// Promotion contains the piece type a pawn turns into upon reaching the
+	// end of the board, or 0 if this move is not a promotion
+	Promotion PieceType
+
 	// Time is the time since the start of the match at which the move occurred
 	Time time.Duration
 }
 
+// IsDrop tests if this move places a piece from the reserve onto the board
+func (m Move) IsDrop() bool {
+	return m.From == nil && m.To != nil
+}
+
+// IsHidden tests if the details of this move were left out, because it was
+// made out of sight of the player it is shown to
+func (m Move) IsHidden() bool {
+	return m.To == nil
+}
+
+// SameSquares tests if two moves go from and to the same positions. Drops
+// also have to place the same piece type.
+func (m Move) SameSquares(o Move) bool {
+	if m.IsHidden() || o.IsHidden() {
+		return false
+	} else if m.IsDrop() || o.IsDrop() {
+		return m.IsDrop() && o.IsDrop() && m.PieceType == o.PieceType && m.To.Equals(o.To)
+	}
+	return m.From.Equals(o.From) && m.To.Equals(o.To)
+}
+
 func (m Move) String() string {
+	rv := fmt.Sprintf("%s %s %s", m.PieceType, m.From, m.To)
+	if m.IsHidden() {
+		rv = "(hidden)"
+	} else if m.IsDrop() {
+		rv = fmt.Sprintf("%s @%s", m.PieceType, m.To)
+	}
+	if m.Promotion != 0 {
+		rv += fmt.Sprintf("=%s", m.Promotion)
+	}
+
+	return rv + m.timeSuffix()
+}
+
+// timeSuffix formats the time at which a move occurred, for display after the move itself
+func (m Move) timeSuffix() string {
 	if m.Time == 0 {
-		return fmt.Sprintf("%s %s %s", m.PieceType, m.From, m.To)
+		return ""
 	}
 
 	t0 := m.Time.Truncate(100 * time.Millisecond)
@@ -119,7 +362,30 @@ func (m Move) String() string {
 		t0 = m.Time.Truncate(time.Second)
 	}
 
-	return fmt.Sprintf("%s %s %s  +%s", m.PieceType, m.From, m.To, t0)
+	return fmt.Sprintf("  +%s", t0)
+}
+
+// validPromotion tests if a pawn is allowed to turn into this piece type
+func validPromotion(pt PieceType) bool {
+	return pt == QUEEN || pt == ROOK || pt == BISHOP || pt == KNIGHT
+}
+
+// promote changes the type of the piece at the specified position
+func (b Board) promote(pos Position, pt PieceType) Board {
+	rv := b
+	rv.Pieces = make([]Piece, len(b.Pieces))
+	rv.pieceHash = b.piecesHash()
+	promoted := b.pieceIndex(pos)
+	for i, p := range b.Pieces {
+		if i == promoted {
+			rv.pieceHash ^= zobristPiece(p)
+			p.PieceType = pt
+			rv.pieceHash ^= zobristPiece(p)
+		}
+		rv.Pieces[i] = p
+	}
+	rv.index = newBoardIndex(rv.Pieces)
+	return rv
 }
 
 // The RuleSet captures the details in a chess variant
@@ -144,10 +410,16 @@ type RuleSet interface {
 
 	// ApplyMove performs a move on the board, and returns the resulting board
 	ApplyMove(Board, Move) (Board, error)
+
+	// Status determines whether the player whose turn it is is in check,
+	// checkmate, or stalemate
+	Status(Board) Status
 }
 
 var registeredRuleSets map[string]func() RuleSet
 
+var registeredRuleSetFamilies map[string]func(string) RuleSet
+
 // RegisterRuleSet adds a named rule set to the registry
 func RegisterRuleSet(name string, f func() RuleSet) {
 	if registeredRuleSets == nil {
@@ -159,16 +431,31 @@ func RegisterRuleSet(name string, f func() RuleSet) {
 	registeredRuleSets[name] = f
 }
 
+// RegisterRuleSetFamily adds a named family of rule sets to the registry. A
+// member of the family is retrieved using the name 'Family:parameter'. The
+// function should return nil if the parameter is invalid.
+func RegisterRuleSetFamily(name string, f func(param string) RuleSet) {
+	if registeredRuleSetFamilies == nil {
+		registeredRuleSetFamilies = make(map[string]func(string) RuleSet)
+	}
+	if _, ok := registeredRuleSetFamilies[name]; ok {
+		panic(fmt.Sprintf("The rule set family '%s' is already registered", name))
+	}
+	registeredRuleSetFamilies[name] = f
+}
+
 // GetRuleSet retrieves a named rule set, and creates a new instance
 func GetRuleSet(name string) RuleSet {
-	if registeredRuleSets == nil {
-		return nil
+	if f, ok := registeredRuleSets[name]; ok {
+		return f()
 	}
-	f, ok := registeredRuleSets[name]
-	if !ok {
-		return nil
+
+	if i := strings.IndexByte(name, ':'); i >= 0 {
+		if f, ok := registeredRuleSetFamilies[name[:i]]; ok {
+			return f(name[i+1:])
+		}
 	}
-	return f()
+	return nil
 }
 
 // A Match wraps a chess match
@@ -179,9 +466,72 @@ type Match struct {
 	// The current Board
 	Board Board
 
+	// StartingBoard contains the board at the start of the match, if it
+	// differs from the rule set's default board
+	StartingBoard *Board
+
 	// StartTime records the date and time the match was started
 	StartTime time.Time
 
 	// Moves contains a log of all moves that have been performed
 	Moves []Move
 }
+
+// InitialBoard returns the board at the start of the match
+func (m Match) InitialBoard() Board {
+	if m.StartingBoard != nil {
+		return *m.StartingBoard
+	}
+	return m.RuleSet.DefaultBoard()
+}
+
+// Result returns the final score for each player, in the order of the rule
+// set's PlayerColours, or nil if the match has not yet ended. The players that
+// are still in the game share the points.
+func (m Match) Result() []float64 {
+	// Pieces that are out of sight might still be on the board
+	if m.Board.Partial {
+		return nil
+	}
+
+	colours := m.RuleSet.PlayerColours()
+
+	// Players that have been eliminated get nothing
+	var remaining []Colour
+	for _, c := range colours {
+		if m.Board.hasPieces(c) {
+			remaining = append(remaining, c)
+		}
+	}
+	share := func(winners []Colour) []float64 {
+		rv := make([]float64, len(colours))
+		for i, c := range colours {
+			for _, w := range winners {
+				if c == w {
+					rv[i] = 1.0 / float64(len(winners))
+				}
+			}
+		}
+		return rv
+	}
+
+	if len(remaining) == 1 {
+		return share(remaining)
+	}
+
+	st := m.Status()
+	if st == CHECKMATE {
+		var winners []Colour
+		for _, c := range remaining {
+			if c != m.Board.Turn {
+				winners = append(winners, c)
+			}
+		}
+		return share(winners)
+	} else if st != NORMAL && st != CHECK {
+		// Stalemate and all other draws
+		return share(remaining)
+	}
+
+	return nil
+}
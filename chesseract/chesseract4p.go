@@ -0,0 +1,119 @@
+package chesseract
+
+func init() {
+	RegisterRuleSet("Chesseract4P", func() RuleSet {
+		return Chesseract4P{}
+	})
+}
+
+// Chesseract4P is a four-player version of Chesseract. Each player starts
+// with the same army as in Chesseract, in one of the four corners of the y-w
+// plane, and advances towards the opposite corner.
+//
+// A player is eliminated if their king is captured, or if they are unable to
+// move while more than two players remain. An eliminated player's pieces are
+// removed from the board, and they are skipped from then on. Once two players
+// remain, the game continues as a regular match.
+type Chesseract4P struct{}
+
+func (Chesseract4P) String() string {
+	return "Chesseract4P"
+}
+
+// PlayerColours returns the colours in turn order, which goes around the
+// corners of the y-w plane
+func (Chesseract4P) PlayerColours() []Colour {
+	return []Colour{WHITE, RED, BLACK, BLUE}
+}
+
+// DefaultBoard sets up the initial board configuration, by copying white's
+// army from Chesseract into each player's corner
+func (rs Chesseract4P) DefaultBoard() Board {
+	rv := Board{
+		Turn: WHITE,
+	}
+
+	for _, c := range rs.PlayerColours() {
+		y, w := homeCorner4d(c)
+		dy, dw := pawnDirections4d(c)
+		for _, p := range (Chesseract{}).DefaultBoard().Pieces {
+			if p.Colour != WHITE {
+				continue
+			}
+			pos := p.Position.(position4D)
+			rv.Pieces = append(rv.Pieces, Piece{
+				PieceType: p.PieceType,
+				Colour:    c,
+				Position:  position4D{pos[0], y + dy*pos[1], pos[2], w + dw*pos[3]},
+			})
+		}
+	}
+
+	return rv
+}
+
+func (Chesseract4P) AllPositions() []Position {
+	return Chesseract{}.AllPositions()
+}
+
+func (Chesseract4P) ParsePosition(s string) (Position, error) {
+	return Chesseract{}.ParsePosition(s)
+}
+
+// CanMove tests whether a piece can move to the specified new position on the board.
+// Note: this only tests movement rules; the check check is performed elsewhere.
+func (Chesseract4P) CanMove(board Board, piece Piece, pos Position) bool {
+	return Chesseract{}.CanMove(board, piece, pos)
+}
+
+// LegalMoves returns all legal moves for the player whose turn it is. Whether
+// or not a move is legal doesn't depend on the turn order, so this uses the
+// two-player rules.
+func (Chesseract4P) LegalMoves(board Board) []Move {
+	return Chesseract{}.LegalMoves(board)
+}
+
+// ApplyMove performs a move on the board, eliminates any players that are
+// out of the game as a result, and passes the turn to the next player.
+func (rs Chesseract4P) ApplyMove(board Board, move Move) (Board, error) {
+	newBoard, err := Chesseract{}.applyMove(board, move)
+	if err != nil {
+		return Board{}, err
+	}
+
+	colours := rs.PlayerColours()
+
+	// Players whose king got captured are out
+	for _, c := range colours {
+		if newBoard.hasPieces(c) && !newBoard.hasKing(c) {
+			newBoard = newBoard.removeColour(c)
+		}
+	}
+	newBoard = newBoard.nextTurn(colours)
+
+	// Players that can't move are out too, unless that would end the game
+	targets := func(p Piece) []Position {
+		return Chesseract{}.targets(newBoard, p)
+	}
+	for rs.playersLeft(newBoard) > 2 && !hasLegalMove(Chesseract{}, newBoard, targets) {
+		newBoard = newBoard.removeColour(newBoard.Turn).nextTurn(colours)
+	}
+
+	return newBoard, nil
+}
+
+// playersLeft counts the players that are still in the game
+func (rs Chesseract4P) playersLeft(board Board) int {
+	rv := 0
+	for _, c := range rs.PlayerColours() {
+		if board.hasPieces(c) {
+			rv++
+		}
+	}
+	return rv
+}
+
+// Status determines whether the player whose turn it is is in check, checkmate, or stalemate
+func (rs Chesseract4P) Status(board Board) Status {
+	return gameStatus(rs, board)
+}
@@ -0,0 +1,155 @@
+package chesseract
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChesseract4PDefaultBoard(t *testing.T) {
+	rs := Chesseract4P{}
+	board := rs.DefaultBoard()
+
+	count := make(map[Colour]int)
+	kings := make(map[Colour]int)
+	for _, p := range rs.AllPositions() {
+		if pc, ok := board.At(p); ok {
+			count[pc.Colour]++
+			if pc.PieceType == KING {
+				kings[pc.Colour]++
+			}
+		}
+	}
+
+	if len(board.Pieces) != 4*54 {
+		t.Errorf("Expected %d pieces; got %d", 4*54, len(board.Pieces))
+	}
+	for _, c := range rs.PlayerColours() {
+		if count[c] != 54 || kings[c] != 1 {
+			t.Errorf("%s has %d pieces and %d kings", c, count[c], kings[c])
+		}
+	}
+
+	if st := rs.Status(board); st != NORMAL {
+		t.Errorf("Unexpected status '%s' for the default board", st)
+	}
+}
+
+func TestChesseract4PTurnOrder(t *testing.T) {
+	rs := Chesseract4P{}
+	board := rs.DefaultBoard()
+
+	for i, c := range []Colour{WHITE, RED, BLACK, BLUE, WHITE} {
+		if board.Turn != c {
+			t.Fatalf("Ply %d: expected it to be %s's turn; got %s", i, c, board.Turn)
+		}
+		if expected := i / 4; board.FullMoves != expected {
+			t.Errorf("Ply %d: expected %d full moves; got %d", i, expected, board.FullMoves)
+		}
+
+		moves := LegalMoves(rs, board)
+		if len(moves) == 0 {
+			t.Fatalf("%s has no moves", c)
+		}
+		newBoard, err := rs.ApplyMove(board, moves[0])
+		if err != nil {
+			t.Fatalf("error applying move %s: %v", moves[0], err)
+		}
+		board = newBoard
+	}
+}
+
+func TestChesseract4PElimination(t *testing.T) {
+	rs := Chesseract4P{}
+
+	// White captures red's king
+	board := Board{
+		Pieces: []Piece{
+			{KING, WHITE, position4D{5, 5, 5, 5}},
+			{ROOK, WHITE, position4D{0, 5, 0, 0}},
+			{KING, RED, position4D{0, 0, 0, 0}},
+			{PAWN, RED, position4D{2, 2, 2, 2}},
+			{KING, BLACK, position4D{5, 3, 5, 3}},
+			{KING, BLUE, position4D{3, 5, 3, 5}},
+		},
+		Turn: WHITE,
+	}
+	newBoard, err := rs.ApplyMove(board, Move{ROOK, position4D{0, 5, 0, 0}, position4D{0, 0, 0, 0}, 0, 0})
+	if err != nil {
+		t.Fatalf("error capturing king: %v", err)
+	}
+	if newBoard.hasPieces(RED) {
+		t.Errorf("Red should be out of the game")
+	}
+	if newBoard.Turn != BLACK {
+		t.Errorf("Expected it to be black's turn; got %s", newBoard.Turn)
+	}
+
+	// Red's king is trapped by black's rooks, so red is out after white moves
+	board = Board{
+		Pieces: []Piece{
+			{KING, WHITE, position4D{5, 5, 5, 5}},
+			{KING, RED, position4D{0, 0, 0, 0}},
+			{KING, BLACK, position4D{5, 3, 5, 3}},
+			{KING, BLUE, position4D{3, 5, 3, 5}},
+		},
+		Turn: WHITE,
+	}
+	for i := 1; i < 16; i++ {
+		board.Pieces = append(board.Pieces, Piece{ROOK, BLACK, position4D{i & 1, (i >> 1) & 1, (i >> 2) & 1, (i >> 3) & 1}})
+	}
+	newBoard, err = rs.ApplyMove(board, Move{KING, position4D{5, 5, 5, 5}, position4D{5, 5, 5, 4}, 0, 0})
+	if err != nil {
+		t.Fatalf("error moving king: %v", err)
+	}
+	if newBoard.hasPieces(RED) {
+		t.Errorf("Red should be out of the game")
+	}
+	if newBoard.Turn != BLACK {
+		t.Errorf("Expected it to be black's turn; got %s", newBoard.Turn)
+	}
+
+	// Once white and blue are out, the same position is checkmate
+	board.Pieces = board.Pieces[1:]
+	board.Pieces = append(board.Pieces[:2], board.Pieces[3:]...)
+	board.Turn = RED
+	match := Match{
+		RuleSet: rs,
+		Board:   board,
+	}
+	if st := match.Status(); st != CHECKMATE {
+		t.Errorf("Expected checkmate; got %s", st)
+	}
+	res := match.Result()
+	if len(res) != 4 || res[0] != 0 || res[1] != 0 || res[2] != 1 || res[3] != 0 {
+		t.Errorf("Unexpected result %v", res)
+	}
+}
+
+func TestMarshalChesseract4P(t *testing.T) {
+	rs := Chesseract4P{}
+	match := Match{
+		RuleSet: rs,
+		Board:   rs.DefaultBoard(),
+	}
+	for i := 0; i < 3; i++ {
+		move := LegalMoves(rs, match.Board)[0]
+		newBoard, err := rs.ApplyMove(match.Board, move)
+		if err != nil {
+			t.Fatalf("error applying move %s: %v", move, err)
+		}
+		match.Moves = append(match.Moves, move)
+		match.Board = newBoard
+	}
+
+	b, err := json.Marshal(match)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Match
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.RuleSet != rs || decoded.Board.Turn != BLUE || decoded.Board.Hash() != match.Board.Hash() {
+		t.Errorf("Match doesn't survive a round trip through JSON")
+	}
+}
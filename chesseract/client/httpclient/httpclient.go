@@ -324,8 +324,13 @@ func (s *httpSession) PlayingAs() chesseract.Colour {
 // SubmitMove submits a move by this player.
 func (s *httpSession) SubmitMove(ctx context.Context, mov chesseract.Move) error {
 	req := web.MoveRequest{
-		From: mov.From.String(),
-		To:   mov.To.String(),
+		To:        mov.To.String(),
+		Promotion: mov.Promotion,
+	}
+	if mov.IsDrop() {
+		req.Drop = mov.PieceType
+	} else {
+		req.From = mov.From.String()
 	}
 	return s.post(ctx, nil, "/api/game/move", nil, req)
 }
@@ -338,17 +343,19 @@ func (s *httpSession) NextMove(ctx context.Context) (chesseract.Move, error) {
 	v.Set("nextindex", fmt.Sprintf("%d", len(s.game.Match.Moves)))
 
 	var rv struct {
-		Move struct {
+		Move *struct {
 			PieceType chesseract.PieceType `json:"type"`
 			From      string               `json:"from"`
 			To        string               `json:"to"`
+			Promotion chesseract.PieceType `json:"promotion,omitempty"`
 			Time      string               `json:"time,omitempty"`
 		}
+		Game *game.Game
 	}
 
 	first := true
 
-	for ctx.Err() == nil && rv.Move.From == "" && rv.Move.To == "" {
+	for ctx.Err() == nil && rv.Move == nil {
 		err := s.get(ctx, &rv, "/api/game/next-move", v)
 		if err != nil {
 			return chesseract.Move{}, err
@@ -367,18 +374,30 @@ func (s *httpSession) NextMove(ctx context.Context) (chesseract.Move, error) {
 	rs := s.game.Match.RuleSet
 	mov := chesseract.Move{
 		PieceType: rv.Move.PieceType,
+		Promotion: rv.Move.Promotion,
 	}
-	mov.From, err = rs.ParsePosition(rv.Move.From)
-	if err != nil {
-		return chesseract.Move{}, nil
+	if rv.Move.From != "" {
+		mov.From, err = rs.ParsePosition(rv.Move.From)
+		if err != nil {
+			return chesseract.Move{}, nil
+		}
 	}
-	mov.To, err = rs.ParsePosition(rv.Move.To)
-	if err != nil {
-		return chesseract.Move{}, nil
+	if rv.Move.To != "" {
+		mov.To, err = rs.ParsePosition(rv.Move.To)
+		if err != nil {
+			return chesseract.Move{}, nil
+		}
 	}
 
 	mov.Time, _ = time.ParseDuration(rv.Move.Time)
 
+	// With a fog of war, the board is only partially visible, so the move
+	// can't be replayed. Take the server's view of the game instead.
+	if rv.Game != nil {
+		*s.game = *rv.Game
+		return mov, nil
+	}
+
 	// Interface rules: we need to apply this to the internal game object
 	newb, err := rs.ApplyMove(s.game.Match.Board, mov)
 	if err != nil {
@@ -0,0 +1,138 @@
+package chesseract
+
+func init() {
+	RegisterRuleSet("Crazyhouse", func() RuleSet {
+		return Crazyhouse{}
+	})
+}
+
+// The Crazyhouse type implements the drop variant of the old 2D board. A
+// captured piece changes sides, and joins the reserve of the player that
+// captured it. Instead of moving a piece on the board, a player can drop a
+// piece from their reserve onto any empty square. Pawns can't be dropped on
+// the first or the last rank.
+//
+// Unlike the official rules, promoted pieces keep their type when they are
+// captured, rather than turning back into pawns.
+type Crazyhouse struct{}
+
+// rules returns the underlying 2D rule set
+func (Crazyhouse) rules() Boring2D {
+	return Boring2D{}
+}
+
+func (Crazyhouse) String() string {
+	return "Crazyhouse"
+}
+
+func (rs Crazyhouse) PlayerColours() []Colour {
+	return rs.rules().PlayerColours()
+}
+
+// DefaultBoard sets up the initial board configuration
+func (rs Crazyhouse) DefaultBoard() Board {
+	return rs.rules().DefaultBoard()
+}
+
+// AllPositions returns an iterator that allows one to range over all possible positions on the board in this variant
+func (rs Crazyhouse) AllPositions() []Position {
+	return rs.rules().AllPositions()
+}
+
+// ParsePosition converts a string representation into a Position of the correct type
+func (rs Crazyhouse) ParsePosition(s string) (Position, error) {
+	return rs.rules().ParsePosition(s)
+}
+
+// CanMove tests whether a piece can move to the specified new position on the board.
+// Note: this only tests movement rules; the check check is performed elsewhere.
+func (rs Crazyhouse) CanMove(board Board, piece Piece, pos Position) bool {
+	return rs.rules().CanMove(board, piece, pos)
+}
+
+// LegalMoves returns all legal moves for the player whose turn it is,
+// including drops
+func (rs Crazyhouse) LegalMoves(board Board) []Move {
+	rv := rs.rules().LegalMoves(board)
+
+	seen := make(map[PieceType]bool)
+	for _, p := range board.Reserve {
+		if p.Colour != board.Turn || seen[p.PieceType] {
+			continue
+		}
+		seen[p.PieceType] = true
+
+		for _, pos := range rs.AllPositions() {
+			move := Move{PieceType: p.PieceType, To: pos}
+			if _, err := rs.ApplyMove(board, move); err == nil {
+				rv = append(rv, move)
+			}
+		}
+	}
+
+	return rv
+}
+
+// ApplyMove performs a move on the board, and returns the resulting board
+func (rs Crazyhouse) ApplyMove(board Board, move Move) (Board, error) {
+	if move.IsDrop() {
+		return rs.applyDrop(board, move)
+	}
+
+	piece, ok := board.At(move.From)
+	if !ok {
+		return Board{}, errIllegalMove
+	}
+
+	// Find out what gets captured before the board changes
+	var captured PieceType
+	if target, ok := board.At(move.To); ok && target.Colour != piece.Colour {
+		captured = target.PieceType
+	} else if piece.PieceType == PAWN && board.EnPassant.canCapture(board, piece.Colour, move.To) {
+		captured = PAWN
+	}
+
+	newBoard, err := rs.rules().ApplyMove(board, move)
+	if err != nil {
+		return Board{}, err
+	}
+
+	if captured != 0 {
+		newBoard = newBoard.addToReserve(piece.Colour, captured)
+	}
+	return newBoard, nil
+}
+
+// applyDrop places a piece from the reserve of the player whose turn it is
+// onto an empty square
+func (rs Crazyhouse) applyDrop(board Board, move Move) (Board, error) {
+	to, ok := move.To.(position2D)
+	if !ok || move.Promotion != 0 {
+		return Board{}, errIllegalMove
+	}
+	if _, ok := board.At(to); ok {
+		return Board{}, errIllegalMove
+	}
+	if move.PieceType == PAWN && (to[1] == 0 || to[1] == 7) {
+		return Board{}, errIllegalMove
+	}
+
+	newBoard, ok := board.takeFromReserve(board.Turn, move.PieceType)
+	if !ok {
+		return Board{}, errIllegalMove
+	}
+	newBoard = newBoard.placePiece(Piece{move.PieceType, board.Turn, to})
+	newBoard.EnPassant = EnPassant{}
+	newBoard.HalfMoveClock++
+
+	if inCheck(rs, newBoard, board.Turn) {
+		return Board{}, errIllegalMove
+	}
+
+	return newBoard.nextTurn(rs.PlayerColours()), nil
+}
+
+// Status determines whether the player whose turn it is is in check, checkmate, or stalemate
+func (rs Crazyhouse) Status(board Board) Status {
+	return gameStatus(rs, board)
+}
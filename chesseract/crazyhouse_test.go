@@ -0,0 +1,196 @@
+package chesseract
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCrazyhouseCapture(t *testing.T) {
+	rs := Crazyhouse{}
+	board, err := ParseFEN("4k3/8/8/3p4/4P3/8/8/4K3 w - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	move, err := ParseSAN(rs, board, "exd5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	board, err = rs.ApplyMove(board, move)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(board.Reserve) != 1 || board.Reserve[0].PieceType != PAWN || board.Reserve[0].Colour != WHITE {
+		t.Errorf("Unexpected reserve after capture: %v", board.Reserve)
+	}
+
+	// En passant captures count too
+	board, err = ParseFEN("4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	board, err = rs.ApplyMove(board, Move{PAWN, position2D{4, 4}, position2D{3, 5}, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(board.Reserve) != 1 || board.Reserve[0].PieceType != PAWN || board.Reserve[0].Colour != WHITE {
+		t.Errorf("Unexpected reserve after en passant: %v", board.Reserve)
+	}
+}
+
+func TestCrazyhouseDrops(t *testing.T) {
+	rs := Crazyhouse{}
+	board, err := ParseFEN("4k3/8/8/8/8/8/8/4K3[NPn] w - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	illegal := []Move{
+		{KNIGHT, nil, position2D{4, 7}, 0, 0},
+		{PAWN, nil, position2D{0, 7}, 0, 0},
+		{PAWN, nil, position2D{0, 0}, 0, 0},
+		{QUEEN, nil, position2D{3, 3}, 0, 0},
+		{PAWN, nil, position2D{3, 3}, QUEEN, 0},
+	}
+	for _, move := range illegal {
+		if _, err := rs.ApplyMove(board, move); err == nil {
+			t.Errorf("Drop %s should be illegal", move)
+		}
+	}
+
+	newBoard, err := rs.ApplyMove(board, Move{KNIGHT, nil, position2D{5, 2}, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pc, ok := newBoard.At(position2D{5, 2}); !ok || pc.PieceType != KNIGHT || pc.Colour != WHITE {
+		t.Errorf("Expected a white knight on f3; got %v", pc)
+	}
+	if len(newBoard.Reserve) != 2 || newBoard.Turn != BLACK {
+		t.Errorf("Unexpected board after drop: reserve %v, turn %s", newBoard.Reserve, newBoard.Turn)
+	}
+	if newBoard.Hash() == board.Hash() {
+		t.Errorf("Hash doesn't change after a drop")
+	}
+
+	// Black can only drop its own pieces
+	if _, err := rs.ApplyMove(newBoard, Move{PAWN, nil, position2D{3, 3}, 0, 0}); err == nil {
+		t.Errorf("Black shouldn't be able to drop white's pawn")
+	}
+	if _, err := rs.ApplyMove(newBoard, Move{KNIGHT, nil, position2D{3, 3}, 0, 0}); err != nil {
+		t.Errorf("Black should be able to drop a knight: %v", err)
+	}
+}
+
+func TestCrazyhouseBlockCheck(t *testing.T) {
+	rs := Crazyhouse{}
+	for fen, expected := range map[string]Status{
+		"R5k1/5ppp/8/8/8/8/8/6K1 b - - 0 1":    CHECKMATE,
+		"R5k1/5ppp/8/8/8/8/8/6K1[n] b - - 0 1": CHECK,
+		"R5k1/5ppp/8/8/8/8/8/6K1[N] b - - 0 1": CHECKMATE,
+	} {
+		board, err := ParseFEN(fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st := rs.Status(board); st != expected {
+			t.Errorf("%s: expected %s; got %s", fen, expected, st)
+		}
+	}
+}
+
+func TestCrazyhouseNotation(t *testing.T) {
+	rs := Crazyhouse{}
+	board, err := ParseFEN("4k3/8/8/8/8/8/8/4K3[NP] w - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for s, expected := range map[string]string{
+		"N@f3":  "N@f3",
+		"P@e4":  "P@e4",
+		"@e4":   "P@e4",
+		"N@d6+": "N@d6+",
+	} {
+		move, err := ParseSAN(rs, board, s)
+		if err != nil {
+			t.Errorf("error parsing '%s': %v", s, err)
+			continue
+		}
+		if !move.IsDrop() {
+			t.Errorf("Move '%s' should be a drop", s)
+		}
+		if san := SAN(rs, board, move); san != expected {
+			t.Errorf("Move '%s' is written as '%s'; expected '%s'", s, san, expected)
+		}
+		if la := LongAlgebraic(rs, board, move); la != expected {
+			t.Errorf("Move '%s' is written as '%s' in long algebraic notation", s, la)
+		}
+	}
+
+	fen := "r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R[Pp] w KQkq - 2 3"
+	board, err = ParseFEN(fen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := FormatBoard(rs, board); err != nil || s != fen {
+		t.Errorf("FEN '%s' turns into '%s' (%v)", fen, s, err)
+	}
+}
+
+func TestCrazyhousePerft(t *testing.T) {
+	// Nothing can be dropped until after the first capture, so the first
+	// few plies are the same as regular chess
+	rs := Crazyhouse{}
+	for depth, expected := range []int{1, 20, 400, 8902} {
+		if n := Perft(rs, rs.DefaultBoard(), depth); n != expected {
+			t.Errorf("perft(%d) = %d; expected %d", depth, n, expected)
+		}
+	}
+}
+
+func TestMarshalCrazyhouse(t *testing.T) {
+	rs := Crazyhouse{}
+	match := Match{
+		RuleSet: rs,
+		Board:   rs.DefaultBoard(),
+	}
+	for _, s := range []string{"e4", "d5", "exd5", "Qxd5", "P@e4", "Qxe4+"} {
+		move, err := ParseSAN(rs, match.Board, s)
+		if err != nil {
+			t.Fatalf("error parsing '%s': %v", s, err)
+		}
+		newBoard, err := rs.ApplyMove(match.Board, move)
+		if err != nil {
+			t.Fatalf("error applying move '%s': %v", s, err)
+		}
+		match.Moves = append(match.Moves, move)
+		match.Board = newBoard
+	}
+
+	b, err := json.Marshal(match)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Match
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.RuleSet != rs || decoded.Board.Hash() != match.Board.Hash() || len(decoded.Board.Reserve) != 2 {
+		t.Errorf("Match doesn't survive a round trip through JSON")
+	}
+	if !decoded.Moves[4].IsDrop() || decoded.Moves[4].PieceType != PAWN {
+		t.Errorf("Drop turns into %s", decoded.Moves[4])
+	}
+
+	// Replaying the moves should give the same board
+	board := rs.DefaultBoard()
+	for _, move := range decoded.Moves {
+		board, err = rs.ApplyMove(board, move)
+		if err != nil {
+			t.Fatalf("error replaying move %s: %v", move, err)
+		}
+	}
+	if board.Hash() != match.Board.Hash() {
+		t.Errorf("Replayed board differs")
+	}
+}
@@ -0,0 +1,85 @@
+package chesseract
+
+func init() {
+	RegisterRuleSet("DarkChess", func() RuleSet {
+		return DarkChess{}
+	})
+}
+
+// The DarkChess type implements a fog-of-war version of the old 2D board.
+// Each player only sees their own pieces, and the squares those pieces can
+// move to.
+//
+// Unlike most versions of dark chess, the regular rules about check still
+// apply. A move that leaves your king under attack by a piece you can't see
+// is rejected as illegal.
+type DarkChess struct{}
+
+// rules returns the underlying 2D rule set
+func (DarkChess) rules() Boring2D {
+	return Boring2D{}
+}
+
+func (DarkChess) String() string {
+	return "DarkChess"
+}
+
+func (rs DarkChess) PlayerColours() []Colour {
+	return rs.rules().PlayerColours()
+}
+
+// DefaultBoard sets up the initial board configuration
+func (rs DarkChess) DefaultBoard() Board {
+	return rs.rules().DefaultBoard()
+}
+
+// AllPositions returns an iterator that allows one to range over all possible positions on the board in this variant
+func (rs DarkChess) AllPositions() []Position {
+	return rs.rules().AllPositions()
+}
+
+// ParsePosition converts a string representation into a Position of the correct type
+func (rs DarkChess) ParsePosition(s string) (Position, error) {
+	return rs.rules().ParsePosition(s)
+}
+
+// CanMove tests whether a piece can move to the specified new position on the board.
+// Note: this only tests movement rules; the check check is performed elsewhere.
+func (rs DarkChess) CanMove(board Board, piece Piece, pos Position) bool {
+	return rs.rules().CanMove(board, piece, pos)
+}
+
+// LegalMoves returns all legal moves for the player whose turn it is
+func (rs DarkChess) LegalMoves(board Board) []Move {
+	return rs.rules().LegalMoves(board)
+}
+
+// ApplyMove performs a move on the board, and returns the resulting board
+func (rs DarkChess) ApplyMove(board Board, move Move) (Board, error) {
+	return rs.rules().ApplyMove(board, move)
+}
+
+// Status determines whether the player whose turn it is is in check, checkmate, or stalemate
+func (rs DarkChess) Status(board Board) Status {
+	return rs.rules().Status(board)
+}
+
+// Visible lists the positions occupied by the pieces of the specified
+// colour, and all positions those pieces can move to
+func (rs DarkChess) Visible(board Board, colour Colour) []Position {
+	board = board.indexed()
+
+	var rv []Position
+	for _, p := range board.Pieces {
+		if p.Colour != colour {
+			continue
+		}
+		rv = append(rv, p.Position)
+		for _, pos := range rs.rules().targets(board, p) {
+			if rs.CanMove(board, p, pos) {
+				rv = append(rv, pos)
+			}
+		}
+	}
+	return rv
+}
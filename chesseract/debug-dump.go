@@ -3,24 +3,77 @@ package chesseract
 import (
 	"fmt"
 	"io"
+	"strings"
 )
 
-func (match Match) DebugDump(w io.Writer, highlight []Position) {
+// A DumpOption toggles extra output in DebugDump
+type DumpOption int8
+
+const (
+	// DUMP_FEN adds the FEN (or Hyper-FEN) representation of the board
+	DUMP_FEN DumpOption = 1
+)
+
+func (match Match) DebugDump(w io.Writer, highlight []Position, options ...DumpOption) {
+	initial := match.InitialBoard()
+	colours := match.RuleSet.PlayerColours()
+	n, offset := len(colours), 0
+	for i, c := range colours {
+		if c == initial.Turn {
+			offset = i
+		}
+	}
+	board, replayable := initial, true
 	for i, m := range match.Moves {
-		if i%2 == 0 {
-			fmt.Fprintf(w, " %3d: %s\n", 1+i/2, m)
+		// Use algebraic notation if we can follow along with the moves
+		s := m.String()
+		if replayable {
+			newBoard, err := match.RuleSet.ApplyMove(board, m)
+			if err == nil {
+				s = fmt.Sprintf("%-8s%s", SAN(match.RuleSet, board, m), m.timeSuffix())
+				board = newBoard
+			} else {
+				replayable = false
+			}
+		}
+
+		if (i+offset)%n == 0 {
+			fmt.Fprintf(w, " %3d: %s\n", 1+initial.FullMoves+(i+offset)/n, s)
+		} else if i == 0 {
+			fmt.Fprintf(w, " %3d: ...\n      %s\n", 1+initial.FullMoves, s)
 		} else {
-			fmt.Fprintf(w, "      %s\n", m)
+			fmt.Fprintf(w, "      %s\n", s)
 		}
 	}
 
 	if _, ok := match.RuleSet.(Boring2D); ok {
 		match.dumpBoring2DBoard(w, highlight)
+	} else if _, ok := match.RuleSet.(Chess960); ok {
+		match.dumpBoring2DBoard(w, highlight)
+	} else if _, ok := match.RuleSet.(Crazyhouse); ok {
+		match.dumpBoring2DBoard(w, highlight)
+		match.dumpReserve(w)
+	} else if _, ok := match.RuleSet.(DarkChess); ok {
+		match.dumpBoring2DBoard(w, highlight)
+	} else if _, ok := match.RuleSet.(Raumschach); ok {
+		match.dumpRaumschachBoard(w, highlight)
 	} else if _, ok := match.RuleSet.(Chesseract); ok {
 		match.dumpHyperboard(w, highlight)
+	} else if _, ok := match.RuleSet.(Chesseract4P); ok {
+		match.dumpHyperboard(w, highlight)
+	} else if rs, ok := match.RuleSet.(HyperChess); ok {
+		match.dumpHyperChessBoard(w, rs, highlight)
 	} else {
 		match.dumpUnknownBoard(w, highlight)
 	}
+
+	for _, opt := range options {
+		if opt == DUMP_FEN {
+			if s, err := FormatBoard(match.RuleSet, match.Board); err == nil {
+				fmt.Fprintf(w, "FEN: %s\n", s)
+			}
+		}
+	}
 }
 
 func (match Match) dumpCell(w io.Writer, p Position, highlight []Position) {
@@ -52,6 +105,10 @@ func (match Match) dumpCell(w io.Writer, p Position, highlight []Position) {
 		}
 		if pc.Colour == WHITE && r > 256 {
 			r -= 6
+		} else if pc.Colour == RED {
+			w.Write([]byte("\x1b[38;5;160m"))
+		} else if pc.Colour == BLUE {
+			w.Write([]byte("\x1b[38;5;20m"))
 		}
 		fmt.Fprintf(w, " %c ", r)
 	} else {
@@ -83,6 +140,97 @@ func (match Match) dumpBoring2DBoard(w io.Writer, highlight []Position) {
 	fmt.Fprintf(w, "\n")
 }
 
+func (match Match) dumpReserve(w io.Writer) {
+	for _, c := range match.RuleSet.PlayerColours() {
+		fmt.Fprintf(w, "%s reserve:", c)
+		for _, pc := range match.Board.Reserve {
+			if pc.Colour == c {
+				fmt.Fprintf(w, " %s", pc.PieceType.Letter())
+			}
+		}
+		fmt.Fprintf(w, "\n")
+	}
+}
+
+func (match Match) dumpRaumschachBoard(w io.Writer, highlight []Position) {
+	var x, y, z int
+
+	// Draw the levels side by side, from A on the left to E on the right
+	fmt.Fprintf(w, "  ")
+	for z = 0; z < 5; z++ {
+		fmt.Fprintf(w, "  -------%c------- ", 'A'+rune(z))
+	}
+	fmt.Fprintf(w, "\n  ")
+	for z = 0; z < 5; z++ {
+		fmt.Fprintf(w, " +---------------+")
+	}
+	fmt.Fprintf(w, "\n")
+	for y = 4; y >= 0; y-- {
+		fmt.Fprintf(w, "%d ", y+1)
+		for z = 0; z < 5; z++ {
+			fmt.Fprintf(w, " |")
+			for x = 0; x < 5; x++ {
+				match.dumpCell(w, position3D{x, y, z}, highlight)
+			}
+			fmt.Fprintf(w, "|")
+		}
+		fmt.Fprintf(w, " %d\n", y+1)
+	}
+	fmt.Fprintf(w, "  ")
+	for z = 0; z < 5; z++ {
+		fmt.Fprintf(w, " +---------------+")
+	}
+	fmt.Fprintf(w, "\n  ")
+	for z = 0; z < 5; z++ {
+		fmt.Fprintf(w, "  ")
+		for x = 0; x < 5; x++ {
+			fmt.Fprintf(w, " %c ", 'a'+rune(x))
+		}
+		fmt.Fprintf(w, " ")
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+func (match Match) dumpHyperChessBoard(w io.Writer, rs HyperChess, highlight []Position) {
+	// Draw a regular board for each combination of the coordinates beyond
+	// the first two
+	planes := 1
+	for i := 2; i < rs.Dims; i++ {
+		planes *= rs.Size
+	}
+	border := "  +" + strings.Repeat("---", rs.Size) + "+\n"
+
+	for j := 0; j < planes; j++ {
+		p := positionN{dims: rs.Dims, size: rs.Size}
+		k := j
+		for i := 2; i < rs.Dims; i++ {
+			p.c[i] = k % rs.Size
+			k /= rs.Size
+		}
+		if rs.Dims > 2 {
+			fmt.Fprintf(w, "  ..")
+			for i := 2; i < rs.Dims; i++ {
+				fmt.Fprintf(w, "%s", p.coordinate(i))
+			}
+			fmt.Fprintf(w, "\n")
+		}
+
+		fmt.Fprintf(w, "%s", border)
+		for p.c[1] = rs.Size - 1; p.c[1] >= 0; p.c[1]-- {
+			fmt.Fprintf(w, "%2d|", p.c[1]+1)
+			for p.c[0] = 0; p.c[0] < rs.Size; p.c[0]++ {
+				match.dumpCell(w, p, highlight)
+			}
+			fmt.Fprintf(w, "|\n")
+		}
+		fmt.Fprintf(w, "%s   ", border)
+		for x := 0; x < rs.Size; x++ {
+			fmt.Fprintf(w, " %c ", 'a'+rune(x))
+		}
+		fmt.Fprintf(w, "\n")
+	}
+}
+
 func (match Match) dumpHyperboard(out io.Writer, highlight []Position) {
 	match.dumpUnknownBoard(out, highlight)
 	var x, y, z, w int
@@ -104,6 +104,10 @@ func (debugRules) ApplyMove(board Board, move Move) (Board, error) {
 	return board, errIllegalMove
 }
 
+func (debugRules) Status(board Board) Status {
+	return STALEMATE
+}
+
 func TestDumpUnknownBoard(t *testing.T) {
 	rs := debugRules{}
 	board := rs.DefaultBoard()
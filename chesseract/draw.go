@@ -0,0 +1,131 @@
+package chesseract
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// fiftyMoveLimit is the number of moves each player can make without a
+// capture or a pawn advance before the match ends in a draw
+const fiftyMoveLimit = 50
+
+// repetitionLimit is the number of times the same position has to occur
+// before the match ends in a draw
+const repetitionLimit = 3
+
+// PositionKey returns a string that uniquely identifies the position on this
+// board, including whose turn it is and any castling and en passant rights.
+// Two boards with the same key are considered the same position for the
+// purposes of repetition.
+func (b Board) PositionKey() string {
+	pieces := make([]string, len(b.Pieces))
+	for i, p := range b.Pieces {
+		pieces[i] = fmt.Sprintf("%s%s%s", p.Colour, p.PieceType.Letter(), p.Position)
+	}
+	sort.Strings(pieces)
+
+	castling := make([]string, len(b.Castling))
+	for i, pos := range b.Castling {
+		castling[i] = pos.String()
+	}
+	sort.Strings(castling)
+
+	ep := "-"
+	if b.EnPassant.Target != nil {
+		ep = b.EnPassant.Target.String()
+	}
+
+	key := fmt.Sprintf("%s/%s/%s/%s", strings.Join(pieces, ","), b.Turn, strings.Join(castling, ","), ep)
+	if len(b.Reserve) > 0 {
+		reserve := make([]string, len(b.Reserve))
+		for i, p := range b.Reserve {
+			reserve[i] = fmt.Sprintf("%s%s", p.Colour, p.PieceType.Letter())
+		}
+		sort.Strings(reserve)
+		key += "/" + strings.Join(reserve, ",")
+	}
+	return key
+}
+
+// Repetitions returns the number of times the current position has occurred
+// in this match, including the current occurrence.
+func (m Match) Repetitions() int {
+	hash := m.Board.Hash()
+
+	board := m.InitialBoard()
+	rv := 0
+	if board.Hash() == hash {
+		rv++
+	}
+	for _, move := range m.Moves {
+		var err error
+		board, err = m.RuleSet.ApplyMove(board, move)
+		if err != nil {
+			// The move history doesn't add up; give up
+			return 1
+		}
+		if board.Hash() == hash {
+			rv++
+		}
+	}
+
+	if rv == 0 {
+		return 1
+	}
+	return rv
+}
+
+// insufficientMaterial tests if none of the players has enough pieces left to
+// deliver checkmate. That is the case if there are no pawns, rooks, or queens
+// left, and either there is at most one minor piece on the board, or all
+// minor pieces are bishops that move on the same colour. Any pieces in reserve
+// can still be dropped, so they always count as sufficient.
+func insufficientMaterial(b Board) bool {
+	if len(b.Reserve) > 0 {
+		return false
+	}
+
+	minors := 0
+	bishopColours := make(map[Colour]bool)
+	knights := 0
+	for _, p := range b.Pieces {
+		if p.PieceType == KING {
+			continue
+		} else if p.PieceType == BISHOP {
+			minors++
+			bishopColours[p.Position.CellColour()] = true
+		} else if p.PieceType == KNIGHT {
+			minors++
+			knights++
+		} else {
+			return false
+		}
+	}
+
+	return minors <= 1 || (knights == 0 && len(bishopColours) == 1)
+}
+
+// Status determines the state of the match. In addition to the statuses
+// returned by the rule set, this also checks for draws by the fifty-move
+// rule, threefold repetition, and insufficient material.
+func (m Match) Status() Status {
+	st := m.RuleSet.Status(m.Board)
+	if st == CHECKMATE || st == STALEMATE || m.Board.Partial {
+		return st
+	}
+
+	if insufficientMaterial(m.Board) {
+		return INSUFFICIENT_MATERIAL
+	}
+	if m.Board.HalfMoveClock >= fiftyMoveLimit*len(m.RuleSet.PlayerColours()) {
+		return FIFTY_MOVE_RULE
+	}
+	// A position can't recur until at least four moves have passed without
+	// captures or pawn advances, so skip replaying the match until then
+	if m.Board.HalfMoveClock >= 4 && m.Repetitions() >= repetitionLimit {
+		return REPETITION
+	}
+
+	return st
+}
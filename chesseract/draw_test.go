@@ -0,0 +1,116 @@
+package chesseract
+
+import (
+	"testing"
+)
+
+func TestRepetition(t *testing.T) {
+	rs := Boring2D{}
+	match := Match{
+		RuleSet: rs,
+		Board:   rs.DefaultBoard(),
+	}
+
+	shuffle := []Move{
+		{KNIGHT, position2D{6, 0}, position2D{5, 2}, 0, 0},
+		{KNIGHT, position2D{6, 7}, position2D{5, 5}, 0, 0},
+		{KNIGHT, position2D{5, 2}, position2D{6, 0}, 0, 0},
+		{KNIGHT, position2D{5, 5}, position2D{6, 7}, 0, 0},
+	}
+
+	for i := 0; i < 2; i++ {
+		for _, move := range shuffle {
+			if st := match.Status(); st != NORMAL {
+				t.Fatalf("Unexpected status '%s' after %d moves", st, len(match.Moves))
+			}
+
+			newBoard, err := rs.ApplyMove(match.Board, move)
+			if err != nil {
+				t.Fatalf("error applying move %s: %v", move, err)
+			}
+			match.Board = newBoard
+			match.Moves = append(match.Moves, move)
+		}
+	}
+
+	if n := match.Repetitions(); n != 3 {
+		t.Errorf("Starting position occurred %d times; expected 3", n)
+	}
+	if st := match.Status(); st != REPETITION {
+		t.Errorf("Unexpected status '%s'", st)
+	}
+	if res := match.Result(); len(res) != 2 || res[0] != 0.5 || res[1] != 0.5 {
+		t.Errorf("Unexpected result %v", res)
+	}
+}
+
+func TestFiftyMoveRule(t *testing.T) {
+	rs := Boring2D{}
+	board := Board{
+		Pieces: []Piece{
+			{KING, WHITE, position2D{4, 0}},
+			{ROOK, WHITE, position2D{0, 0}},
+			{PAWN, WHITE, position2D{0, 1}},
+			{KING, BLACK, position2D{4, 7}},
+			{ROOK, BLACK, position2D{7, 7}},
+		},
+		Turn:          WHITE,
+		HalfMoveClock: 98,
+	}
+
+	b1, err := rs.ApplyMove(board, Move{ROOK, position2D{0, 0}, position2D{1, 0}, 0, 0})
+	if err != nil {
+		t.Fatalf("error moving rook: %v", err)
+	}
+	if b1.HalfMoveClock != 99 {
+		t.Errorf("Half-move clock is %d; expected 99", b1.HalfMoveClock)
+	}
+	if st := (Match{RuleSet: rs, Board: b1}).Status(); st != NORMAL {
+		t.Errorf("Unexpected status '%s' after 99 moves", st)
+	}
+
+	b2, err := rs.ApplyMove(b1, Move{ROOK, position2D{7, 7}, position2D{6, 7}, 0, 0})
+	if err != nil {
+		t.Fatalf("error moving rook: %v", err)
+	}
+	if st := (Match{RuleSet: rs, Board: b2}).Status(); st != FIFTY_MOVE_RULE {
+		t.Errorf("Unexpected status '%s' after 100 moves", st)
+	}
+
+	// Pawn advances reset the clock
+	b3, err := rs.ApplyMove(board, Move{PAWN, position2D{0, 1}, position2D{0, 3}, 0, 0})
+	if err != nil {
+		t.Fatalf("error advancing pawn: %v", err)
+	}
+	if b3.HalfMoveClock != 0 {
+		t.Errorf("Half-move clock is %d after a pawn advance", b3.HalfMoveClock)
+	}
+}
+
+func TestInsufficientMaterial(t *testing.T) {
+	type testCase struct {
+		RuleSet  RuleSet
+		Pieces   []Piece
+		Expected bool
+	}
+	cases := []testCase{
+		{Boring2D{}, []Piece{{KING, WHITE, position2D{4, 0}}, {KING, BLACK, position2D{4, 7}}}, true},
+		{Boring2D{}, []Piece{{KING, WHITE, position2D{4, 0}}, {KNIGHT, WHITE, position2D{1, 0}}, {KING, BLACK, position2D{4, 7}}}, true},
+		{Boring2D{}, []Piece{{KING, WHITE, position2D{4, 0}}, {BISHOP, WHITE, position2D{2, 0}}, {BISHOP, BLACK, position2D{5, 7}}, {KING, BLACK, position2D{4, 7}}}, true},
+		{Boring2D{}, []Piece{{KING, WHITE, position2D{4, 0}}, {BISHOP, WHITE, position2D{2, 0}}, {BISHOP, BLACK, position2D{2, 7}}, {KING, BLACK, position2D{4, 7}}}, false},
+		{Boring2D{}, []Piece{{KING, WHITE, position2D{4, 0}}, {KNIGHT, WHITE, position2D{1, 0}}, {KNIGHT, WHITE, position2D{6, 0}}, {KING, BLACK, position2D{4, 7}}}, false},
+		{Boring2D{}, []Piece{{KING, WHITE, position2D{4, 0}}, {PAWN, WHITE, position2D{1, 1}}, {KING, BLACK, position2D{4, 7}}}, false},
+		{Chesseract{}, []Piece{{KING, WHITE, position4D{2, 0, 2, 0}}, {BISHOP, WHITE, position4D{1, 0, 1, 0}}, {KING, BLACK, position4D{2, 5, 2, 5}}}, true},
+		{Chesseract{}, []Piece{{KING, WHITE, position4D{2, 0, 2, 0}}, {ROOK, BLACK, position4D{0, 5, 0, 5}}, {KING, BLACK, position4D{2, 5, 2, 5}}}, false},
+	}
+
+	for _, tc := range cases {
+		match := Match{
+			RuleSet: tc.RuleSet,
+			Board:   Board{Pieces: tc.Pieces, Turn: WHITE},
+		}
+		if st := match.Status(); (st == INSUFFICIENT_MATERIAL) != tc.Expected {
+			t.Errorf("Unexpected status '%s' for pieces %v", st, tc.Pieces)
+		}
+	}
+}
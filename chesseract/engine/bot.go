@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/thijzert/chesseract/chesseract"
+	"github.com/thijzert/chesseract/chesseract/client"
+	"github.com/thijzert/chesseract/chesseract/game"
+)
+
+// A MoveFinder picks a move for the player whose turn it is. An Engine is a
+// MoveFinder, but so is anything else that can come up with moves.
+type MoveFinder interface {
+	BestMove(context.Context, chesseract.RuleSet, chesseract.Board) (chesseract.Move, error)
+}
+
+// A Bot is a client.Client that makes its own moves, using a MoveFinder to
+// pick them. It connects to the multiplayer system through another Client.
+type Bot struct {
+	client.Client
+
+	// Engine is used to search for moves
+	Engine MoveFinder
+}
+
+// NewBot creates a Bot that plays through the specified client
+func NewBot(c client.Client, e MoveFinder) *Bot {
+	return &Bot{
+		Client: c,
+		Engine: e,
+	}
+}
+
+// ActiveGames returns the list of games in which the bot is involved
+func (b *Bot) ActiveGames(ctx context.Context) ([]client.GameSession, error) {
+	sessions, err := b.Client.ActiveGames(ctx)
+	rv := make([]client.GameSession, len(sessions))
+	for i, s := range sessions {
+		rv[i] = b.session(s)
+	}
+	return rv, err
+}
+
+// NewGame initialises a Game with the specified players
+func (b *Bot) NewGame(ctx context.Context, rs chesseract.RuleSet, players []game.Player) (client.GameSession, error) {
+	s, err := b.Client.NewGame(ctx, rs, players)
+	if err != nil {
+		return nil, err
+	}
+	return b.session(s), nil
+}
+
+func (b *Bot) session(s client.GameSession) *Session {
+	return &Session{
+		GameSession: s,
+		engine:      b.Engine,
+	}
+}
+
+// A Session is a client.GameSession in which the bot makes its own moves
+type Session struct {
+	client.GameSession
+
+	engine MoveFinder
+}
+
+// NextMove waits until a move occurs, and returns it. If it's the bot's turn,
+// it looks for a move and submits it first.
+func (s *Session) NextMove(ctx context.Context) (chesseract.Move, error) {
+	g := s.Game()
+	if !gameOver(g) && g.Match.Board.Turn == s.PlayingAs() {
+		move, err := s.engine.BestMove(ctx, g.Match.RuleSet, g.Match.Board)
+		if err != nil {
+			return chesseract.Move{}, err
+		}
+		err = s.SubmitMove(ctx, move)
+		if err != nil {
+			return chesseract.Move{}, err
+		}
+	}
+
+	return s.GameSession.NextMove(ctx)
+}
+
+// Play keeps making moves until the game is over
+func (s *Session) Play(ctx context.Context) error {
+	g := s.Game()
+	for ctx.Err() == nil && !gameOver(g) {
+		_, err := s.NextMove(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// gameOver tests if a game has finished, either according to the server or
+// according to the board
+func gameOver(g *game.Game) bool {
+	return g.Result != nil || g.Match.Result() != nil
+}
@@ -0,0 +1,235 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/thijzert/chesseract/chesseract"
+)
+
+// ErrNoMoves is returned when the player whose turn it is can't move
+var ErrNoMoves = errors.New("no legal moves")
+
+// defaultDepth is the search depth used if none is configured
+const defaultDepth = 3
+
+// mateScore is the score of a checkmate. Mates that take fewer moves score
+// slightly higher.
+const mateScore = 1000000
+
+// pieceValues contains the value of each piece type, in centipawns
+var pieceValues = map[chesseract.PieceType]int{
+	chesseract.PAWN:    100,
+	chesseract.KNIGHT:  300,
+	chesseract.BISHOP:  325,
+	chesseract.ROOK:    500,
+	chesseract.QUEEN:   900,
+	chesseract.UNICORN: 250,
+}
+
+// A Config sets the strength of an Engine
+type Config struct {
+	// Depth is the maximum number of plies to search ahead. If it is zero, a
+	// default depth of 3 is used.
+	Depth int
+
+	// MoveTime limits the time spent looking for a single move. If it is
+	// zero, there is no time limit.
+	MoveTime time.Duration
+}
+
+// An Engine searches for the best move using alpha-beta pruning. It only
+// relies on the RuleSet interface, so it can play any variant. An Engine can
+// be used for multiple searches at the same time.
+type Engine struct {
+	config Config
+}
+
+// New creates an Engine with the specified configuration
+func New(config Config) *Engine {
+	if config.Depth <= 0 {
+		config.Depth = defaultDepth
+	}
+	return &Engine{
+		config: config,
+	}
+}
+
+// Config returns the configuration of this engine
+func (e *Engine) Config() Config {
+	return e.config
+}
+
+// BestMove searches for the best move for the player whose turn it is. The
+// search deepens one ply at a time; if the time runs out, the best move from
+// the deepest completed search is returned.
+func (e *Engine) BestMove(ctx context.Context, rs chesseract.RuleSet, board chesseract.Board) (chesseract.Move, error) {
+	moves := chesseract.LegalMoves(rs, board)
+	if len(moves) == 0 {
+		return chesseract.Move{}, ErrNoMoves
+	}
+	orderMoves(board, moves)
+
+	s := &search{
+		ctx: ctx,
+		rs:  rs,
+	}
+	if e.config.MoveTime > 0 {
+		s.deadline = time.Now().Add(e.config.MoveTime)
+	}
+
+	best := moves[0]
+	for depth := 1; depth <= e.config.Depth; depth++ {
+		move, score, ok := s.root(board, moves, depth)
+		if !ok {
+			break
+		}
+		best = move
+
+		// Search the best move first in the next iteration
+		for i, mv := range moves {
+			if mv.SameSquares(best) && mv.Promotion == best.Promotion {
+				copy(moves[1:i+1], moves[:i])
+				moves[0] = best
+				break
+			}
+		}
+
+		// There's no point in looking any further once there's a forced mate
+		if score > mateScore-1000 || score < -mateScore+1000 {
+			break
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return chesseract.Move{}, err
+	}
+	return best, nil
+}
+
+// Evaluate scores a board from the perspective of the player whose turn it
+// is, in centipawns. The score is the difference in material with the
+// strongest opponent, including any pieces in reserve.
+func Evaluate(rs chesseract.RuleSet, board chesseract.Board) int {
+	material := make(map[chesseract.Colour]int)
+	for _, p := range board.Pieces {
+		material[p.Colour] += pieceValues[p.PieceType]
+	}
+	for _, p := range board.Reserve {
+		material[p.Colour] += pieceValues[p.PieceType]
+	}
+
+	opponent, first := 0, true
+	for _, c := range rs.PlayerColours() {
+		if c == board.Turn {
+			continue
+		}
+		if first || material[c] > opponent {
+			opponent = material[c]
+			first = false
+		}
+	}
+
+	return material[board.Turn] - opponent
+}
+
+// orderMoves sorts moves so that the most promising ones come first:
+// captures of valuable pieces by cheap ones, and promotions
+func orderMoves(board chesseract.Board, moves []chesseract.Move) {
+	priority := func(mv chesseract.Move) int {
+		rv := pieceValues[mv.Promotion]
+		if mv.IsDrop() {
+			return rv
+		}
+		if target, ok := board.At(mv.To); ok && target.Colour != board.Turn {
+			rv += 10*pieceValues[target.PieceType] - pieceValues[mv.PieceType]
+		}
+		return rv
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		return priority(moves[i]) > priority(moves[j])
+	})
+}
+
+// A search holds the state of a single search
+type search struct {
+	ctx      context.Context
+	rs       chesseract.RuleSet
+	deadline time.Time
+	aborted  bool
+}
+
+// expired tests if the search should stop
+func (s *search) expired() bool {
+	if s.aborted {
+		return true
+	}
+	if s.ctx.Err() != nil || (!s.deadline.IsZero() && time.Now().After(s.deadline)) {
+		s.aborted = true
+	}
+	return s.aborted
+}
+
+// root searches all moves from the starting position to the specified depth.
+// It returns false if the search was aborted before it completed.
+func (s *search) root(board chesseract.Board, moves []chesseract.Move, depth int) (chesseract.Move, int, bool) {
+	alpha, beta := -2*mateScore, 2*mateScore
+	var best chesseract.Move
+	for _, mv := range moves {
+		newBoard, err := s.rs.ApplyMove(board, mv)
+		if err != nil {
+			continue
+		}
+		score := -s.negamax(newBoard, depth-1, -beta, -alpha, 1)
+		if s.expired() {
+			return chesseract.Move{}, 0, false
+		}
+		if score > alpha {
+			alpha = score
+			best = mv
+		}
+	}
+	return best, alpha, true
+}
+
+// negamax returns the score of a board from the perspective of the player
+// whose turn it is, searching the specified number of plies ahead. In games
+// with more than two players, every opponent is assumed to play against the
+// player to move.
+func (s *search) negamax(board chesseract.Board, depth, alpha, beta, ply int) int {
+	if s.expired() {
+		return 0
+	}
+	if depth == 0 {
+		return Evaluate(s.rs, board)
+	}
+
+	moves := chesseract.LegalMoves(s.rs, board)
+	if len(moves) == 0 {
+		if s.rs.Status(board) == chesseract.CHECKMATE {
+			return -mateScore + ply
+		}
+		return 0
+	}
+	orderMoves(board, moves)
+
+	for _, mv := range moves {
+		newBoard, err := s.rs.ApplyMove(board, mv)
+		if err != nil {
+			continue
+		}
+		score := -s.negamax(newBoard, depth-1, -beta, -alpha, ply+1)
+		if s.aborted {
+			return 0
+		}
+		if score >= beta {
+			return beta
+		}
+		if score > alpha {
+			alpha = score
+		}
+	}
+	return alpha
+}
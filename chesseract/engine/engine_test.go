@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/thijzert/chesseract/chesseract"
+	"github.com/thijzert/chesseract/chesseract/client"
+	"github.com/thijzert/chesseract/chesseract/game"
+)
+
+func TestBestMove(t *testing.T) {
+	rs := chesseract.Boring2D{}
+	e := New(Config{Depth: 2})
+
+	for fen, expected := range map[string]string{
+		"6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1":                            "Ra8#",
+		"4k3/8/8/3q4/8/8/8/3RK3 w - - 0 1":                             "Rxd5",
+		"r3k3/8/8/8/8/8/5PPP/6K1 b - - 0 1":                            "Ra1#",
+		"7k/8/8/8/8/8/1q6/K7 w - - 0 1":                                "Kxb2",
+		"4k3/8/8/8/8/8/3r4/R3K3 w Q - 0 1":                             "Kxd2",
+		"rnbqkbnr/pppp1ppp/8/4p3/3P4/8/PPP1PPPP/RNBQKBNR w KQkq - 0 2": "dxe5",
+	} {
+		board, err := chesseract.ParseFEN(fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		move, err := e.BestMove(context.Background(), rs, board)
+		if err != nil {
+			t.Errorf("%s: %v", fen, err)
+			continue
+		}
+		if san := chesseract.SAN(rs, board, move); san != expected {
+			t.Errorf("%s: expected %s; got %s", fen, expected, san)
+		}
+	}
+
+	// Checkmate and stalemate leave no moves
+	board, _ := chesseract.ParseFEN("R5k1/5ppp/8/8/8/8/8/6K1 b - - 0 1")
+	if _, err := e.BestMove(context.Background(), rs, board); err != ErrNoMoves {
+		t.Errorf("Expected ErrNoMoves; got %v", err)
+	}
+}
+
+func TestMoveTime(t *testing.T) {
+	rs := chesseract.Chesseract{}
+	e := New(Config{Depth: 10, MoveTime: 200 * time.Millisecond})
+
+	start := time.Now()
+	move, err := e.BestMove(context.Background(), rs, rs.DefaultBoard())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("Search took %s", d)
+	}
+	if _, err := rs.ApplyMove(rs.DefaultBoard(), move); err != nil {
+		t.Errorf("Move %s is illegal: %v", move, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := e.BestMove(ctx, rs, rs.DefaultBoard()); err == nil {
+		t.Errorf("Search should fail if the context is cancelled")
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	rs := chesseract.Boring2D{}
+	board := rs.DefaultBoard()
+	if n := Evaluate(rs, board); n != 0 {
+		t.Errorf("Default board should be even; got %d", n)
+	}
+
+	board, _ = chesseract.ParseFEN("4k3/8/8/8/8/8/8/3QK3 b - - 0 1")
+	if n := Evaluate(rs, board); n != -900 {
+		t.Errorf("Black should be a queen down; got %d", n)
+	}
+
+	// In four-player games, the strongest opponent counts
+	rs4 := chesseract.Chesseract4P{}
+	if n := Evaluate(rs4, rs4.DefaultBoard()); n != 0 {
+		t.Errorf("Default board should be even; got %d", n)
+	}
+}
+
+// A fakeSession is a client.GameSession in which the opponent always plays the
+// first legal move
+type fakeSession struct {
+	game    *game.Game
+	colour  chesseract.Colour
+	pending []chesseract.Move
+}
+
+func (f *fakeSession) Game() *game.Game {
+	return f.game
+}
+
+func (f *fakeSession) PlayingAs() chesseract.Colour {
+	return f.colour
+}
+
+func (f *fakeSession) SubmitMove(_ context.Context, move chesseract.Move) error {
+	if f.game.Match.Board.Turn != f.colour {
+		return client.ErrNotYourTurn
+	}
+	return f.apply(move)
+}
+
+func (f *fakeSession) apply(move chesseract.Move) error {
+	newBoard, err := f.game.Match.RuleSet.ApplyMove(f.game.Match.Board, move)
+	if err != nil {
+		return err
+	}
+	f.game.Match.Board = newBoard
+	f.game.Match.Moves = append(f.game.Match.Moves, move)
+	f.pending = append(f.pending, move)
+	return nil
+}
+
+func (f *fakeSession) NextMove(context.Context) (chesseract.Move, error) {
+	if len(f.pending) == 0 {
+		if err := f.apply(chesseract.LegalMoves(f.game.Match.RuleSet, f.game.Match.Board)[0]); err != nil {
+			return chesseract.Move{}, err
+		}
+	}
+	rv := f.pending[0]
+	f.pending = f.pending[1:]
+	return rv, nil
+}
+
+func (f *fakeSession) ProposeResult(context.Context, []float64) error {
+	return nil
+}
+
+func (f *fakeSession) NextProposition(context.Context) ([]float64, error) {
+	return nil, nil
+}
+
+func (f *fakeSession) GetResult(context.Context) ([]float64, error) {
+	return nil, nil
+}
+
+func TestBotSession(t *testing.T) {
+	rs := chesseract.Boring2D{}
+	fake := &fakeSession{
+		game: &game.Game{
+			Match: chesseract.Match{
+				RuleSet: rs,
+				Board:   rs.DefaultBoard(),
+			},
+		},
+		colour: chesseract.BLACK,
+	}
+	bot := NewBot(nil, New(Config{Depth: 1}))
+	s := bot.session(fake)
+
+	for i := 0; i < 8; i++ {
+		move, err := s.NextMove(context.Background())
+		if err != nil {
+			t.Fatalf("Ply %d: %v", i, err)
+		}
+		if i%2 == 1 && move.PieceType == 0 {
+			t.Errorf("Ply %d: unexpected move %s", i, move)
+		}
+	}
+	if n := len(fake.game.Match.Moves); n != 8 {
+		t.Errorf("Expected 8 moves; got %d", n)
+	}
+}
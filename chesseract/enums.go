@@ -1,10 +1,17 @@
 package chesseract
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 const (
 	BLACK Colour = 1
 	WHITE Colour = 2
+
+	// RED and BLUE are the additional colours in four-player variants
+	RED  Colour = 3
+	BLUE Colour = 4
 )
 
 func (c Colour) String() string {
@@ -12,6 +19,10 @@ func (c Colour) String() string {
 		return "black"
 	} else if c == WHITE {
 		return "white"
+	} else if c == RED {
+		return "red"
+	} else if c == BLUE {
+		return "blue"
 	} else {
 		return fmt.Sprintf("0x%02x", int8(c))
 	}
@@ -24,6 +35,10 @@ const (
 	KNIGHT PieceType = 4
 	ROOK   PieceType = 5
 	PAWN   PieceType = 6
+
+	// UNICORN moves along the triagonals in 3D variants, changing all three
+	// coordinates at once
+	UNICORN PieceType = 7
 )
 
 func (p PieceType) String() string {
@@ -39,7 +54,71 @@ func (p PieceType) String() string {
 		return "♜"
 	} else if p == PAWN {
 		return "♟"
+	} else if p == UNICORN {
+		// There's no unicorn in Unicode, so use a knight lying on its side
+		return "🨊"
 	} else {
 		return fmt.Sprintf("0x%02x", int8(p))
 	}
 }
+
+// ParsePieceType converts a piece's letter (K, Q, B, N, R, P, or U) or glyph into a PieceType
+func ParsePieceType(s string) (PieceType, error) {
+	for _, p := range []PieceType{KING, QUEEN, BISHOP, KNIGHT, ROOK, PAWN, UNICORN} {
+		if s == p.String() || strings.EqualFold(s, p.Letter()) {
+			return p, nil
+		}
+	}
+	return 0, errInvalidFormat
+}
+
+// Letter returns the letter commonly used to denote this piece type in chess notation
+func (p PieceType) Letter() string {
+	if p == KING {
+		return "K"
+	} else if p == QUEEN {
+		return "Q"
+	} else if p == BISHOP {
+		return "B"
+	} else if p == KNIGHT {
+		return "N"
+	} else if p == ROOK {
+		return "R"
+	} else if p == PAWN {
+		return "P"
+	} else if p == UNICORN {
+		return "U"
+	} else {
+		return "?"
+	}
+}
+
+const (
+	NORMAL                Status = 0
+	CHECK                 Status = 1
+	CHECKMATE             Status = 2
+	STALEMATE             Status = 3
+	FIFTY_MOVE_RULE       Status = 4
+	REPETITION            Status = 5
+	INSUFFICIENT_MATERIAL Status = 6
+)
+
+func (s Status) String() string {
+	if s == NORMAL {
+		return "normal"
+	} else if s == CHECK {
+		return "check"
+	} else if s == CHECKMATE {
+		return "checkmate"
+	} else if s == STALEMATE {
+		return "stalemate"
+	} else if s == FIFTY_MOVE_RULE {
+		return "fifty-move rule"
+	} else if s == REPETITION {
+		return "repetition"
+	} else if s == INSUFFICIENT_MATERIAL {
+		return "insufficient material"
+	} else {
+		return fmt.Sprintf("0x%02x", int8(s))
+	}
+}
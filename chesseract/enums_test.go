@@ -6,9 +6,9 @@ import (
 )
 
 func TestColourStringer(t *testing.T) {
-	exp := " black white 0x03"
+	exp := " black white red blue 0x05"
 	rv := ""
-	for i := uint8(1); i < 4; i++ {
+	for i := uint8(1); i < 6; i++ {
 		rv += fmt.Sprintf(" %s", Colour(i))
 	}
 
@@ -19,9 +19,9 @@ func TestColourStringer(t *testing.T) {
 }
 
 func TestPieceTypeStringer(t *testing.T) {
-	exp := " ♚ ♛ ♝ ♞ ♜ ♟ 0x07"
+	exp := " ♚ ♛ ♝ ♞ ♜ ♟ 🨊 0x08"
 	rv := ""
-	for i := uint8(1); i < 8; i++ {
+	for i := uint8(1); i < 9; i++ {
 		rv += fmt.Sprintf(" %s", PieceType(i))
 	}
 
@@ -0,0 +1,283 @@
+package chesseract
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseFEN decodes a position in Forsyth-Edwards Notation into a board for the
+// Boring2D rule set. Pieces in reserve, as used in Crazyhouse, follow the
+// piece placement in square brackets, e.g. 'RNBQKBNR[Qn]'.
+func ParseFEN(s string) (Board, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 4 {
+		return Board{}, fmt.Errorf("invalid FEN '%s': expected at least 4 fields", s)
+	}
+
+	rv := Board{}
+
+	placement := fields[0]
+	if i := strings.IndexByte(placement, '['); i >= 0 && strings.HasSuffix(placement, "]") {
+		for _, c := range placement[i+1 : len(placement)-1] {
+			pt, err := ParsePieceType(string(c))
+			if err != nil || pt == KING {
+				return Board{}, fmt.Errorf("invalid FEN '%s': bad piece '%c' in reserve", s, c)
+			}
+			colour := WHITE
+			if c >= 'a' && c <= 'z' {
+				colour = BLACK
+			}
+			rv.Reserve = append(rv.Reserve, Piece{PieceType: pt, Colour: colour})
+		}
+		placement = placement[:i]
+	}
+
+	ranks := strings.Split(placement, "/")
+	if len(ranks) != 8 {
+		return Board{}, fmt.Errorf("invalid FEN '%s': expected 8 ranks", s)
+	}
+	for i, rank := range ranks {
+		y := 7 - i
+		x := 0
+		for _, c := range rank {
+			if c >= '1' && c <= '8' {
+				x += int(c - '0')
+				continue
+			}
+			pt, err := ParsePieceType(string(c))
+			if err != nil || x >= 8 {
+				return Board{}, fmt.Errorf("invalid FEN '%s': bad rank '%s'", s, rank)
+			}
+			colour := WHITE
+			if c >= 'a' && c <= 'z' {
+				colour = BLACK
+			}
+			rv.Pieces = append(rv.Pieces, Piece{pt, colour, position2D{x, y}})
+			x++
+		}
+		if x != 8 {
+			return Board{}, fmt.Errorf("invalid FEN '%s': bad rank '%s'", s, rank)
+		}
+	}
+
+	if fields[1] == "w" {
+		rv.Turn = WHITE
+	} else if fields[1] == "b" {
+		rv.Turn = BLACK
+	} else {
+		return Board{}, fmt.Errorf("invalid FEN '%s': unknown side to move '%s'", s, fields[1])
+	}
+
+	// Castling rights are either KQkq, which refer to the outermost rook on
+	// either side of the king, or the file of the rook (as used in Chess960)
+	if fields[2] != "-" {
+		for _, c := range fields[2] {
+			var rook position2D
+			if c == 'K' {
+				rook = rv.outermostRook(WHITE, 1)
+			} else if c == 'Q' {
+				rook = rv.outermostRook(WHITE, -1)
+			} else if c == 'k' {
+				rook = rv.outermostRook(BLACK, 1)
+			} else if c == 'q' {
+				rook = rv.outermostRook(BLACK, -1)
+			} else if c >= 'A' && c <= 'H' {
+				rook = position2D{int(c - 'A'), 0}
+			} else if c >= 'a' && c <= 'h' {
+				rook = position2D{int(c - 'a'), 7}
+			} else {
+				return Board{}, fmt.Errorf("invalid FEN '%s': unknown castling right '%c'", s, c)
+			}
+			rv.Castling = append(rv.Castling, rook)
+		}
+	}
+
+	if fields[3] != "-" {
+		target, err := Boring2D{}.ParsePosition(fields[3])
+		if err != nil {
+			return Board{}, fmt.Errorf("invalid FEN '%s': bad en passant square '%s'", s, fields[3])
+		}
+		// The pawn that just moved is one step past the target
+		t := target.(position2D)
+		pawn := position2D{t[0], t[1] - 1}
+		if rv.Turn == BLACK {
+			pawn = position2D{t[0], t[1] + 1}
+		}
+		rv.EnPassant = EnPassant{Target: t, Pawn: pawn}
+	}
+
+	if len(fields) > 4 {
+		if _, err := fmt.Sscanf(fields[4], "%d", &rv.HalfMoveClock); err != nil {
+			return Board{}, fmt.Errorf("invalid FEN '%s': bad half-move clock '%s'", s, fields[4])
+		}
+	}
+	if len(fields) > 5 {
+		var n int
+		if _, err := fmt.Sscanf(fields[5], "%d", &n); err != nil || n < 1 {
+			return Board{}, fmt.Errorf("invalid FEN '%s': bad move number '%s'", s, fields[5])
+		}
+		rv.FullMoves = n - 1
+	}
+
+	return rv, nil
+}
+
+// outermostRook finds the rook furthest away from the king on its back rank,
+// in the direction dx. If there is none, it returns the corner square.
+func (b Board) outermostRook(colour Colour, dx int) position2D {
+	y, x := 0, 7
+	if colour == BLACK {
+		y = 7
+	}
+	if dx < 0 {
+		x = 0
+	}
+
+	for ; x >= 0 && x < 8; x -= dx {
+		pc, ok := b.At(position2D{x, y})
+		if !ok || pc.Colour != colour {
+			continue
+		}
+		if pc.PieceType == ROOK {
+			return position2D{x, y}
+		} else if pc.PieceType == KING {
+			break
+		}
+	}
+
+	if dx < 0 {
+		return position2D{0, y}
+	}
+	return position2D{7, y}
+}
+
+// FEN encodes a Boring2D board in Forsyth-Edwards Notation
+func (b Board) FEN() string {
+	var sb strings.Builder
+
+	for y := 7; y >= 0; y-- {
+		empty := 0
+		for x := 0; x < 8; x++ {
+			pc, ok := b.At(position2D{x, y})
+			if !ok {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				fmt.Fprintf(&sb, "%d", empty)
+				empty = 0
+			}
+			if pc.Colour == BLACK {
+				sb.WriteString(strings.ToLower(pc.PieceType.Letter()))
+			} else {
+				sb.WriteString(pc.PieceType.Letter())
+			}
+		}
+		if empty > 0 {
+			fmt.Fprintf(&sb, "%d", empty)
+		}
+		if y > 0 {
+			sb.WriteByte('/')
+		}
+	}
+
+	if len(b.Reserve) > 0 {
+		sb.WriteByte('[')
+		for _, pc := range b.Reserve {
+			if pc.Colour == BLACK {
+				sb.WriteString(strings.ToLower(pc.PieceType.Letter()))
+			} else {
+				sb.WriteString(pc.PieceType.Letter())
+			}
+		}
+		sb.WriteByte(']')
+	}
+
+	if b.Turn == BLACK {
+		sb.WriteString(" b ")
+	} else {
+		sb.WriteString(" w ")
+	}
+
+	// Use KQkq if that's unambiguous, and the rook's file otherwise
+	castling := ""
+	for _, c := range []struct {
+		Letter string
+		Colour Colour
+		Dx     int
+	}{
+		{"K", WHITE, 1},
+		{"Q", WHITE, -1},
+		{"k", BLACK, 1},
+		{"q", BLACK, -1},
+	} {
+		outermost := b.outermostRook(c.Colour, c.Dx)
+		king := 4
+		for _, pc := range b.Pieces {
+			if p, ok := pc.Position.(position2D); ok && pc.PieceType == KING && pc.Colour == c.Colour && p[1] == outermost[1] {
+				king = p[0]
+			}
+		}
+		for _, r := range b.Castling {
+			p, ok := r.(position2D)
+			if !ok || p[1] != outermost[1] || (p[0]-king)*c.Dx <= 0 {
+				continue
+			}
+			if p == outermost {
+				castling += c.Letter
+			} else if c.Colour == WHITE {
+				castling += string('A' + rune(p[0]))
+			} else {
+				castling += string('a' + rune(p[0]))
+			}
+		}
+	}
+	if castling == "" {
+		castling = "-"
+	}
+	sb.WriteString(castling)
+
+	if b.EnPassant.Target != nil {
+		sb.WriteString(" " + b.EnPassant.Target.String())
+	} else {
+		sb.WriteString(" -")
+	}
+
+	fmt.Fprintf(&sb, " %d %d", b.HalfMoveClock, b.FullMoves+1)
+
+	return sb.String()
+}
+
+// ParseBoard decodes a starting position in the notation appropriate for the
+// rule set: FEN for Boring2D, Chess960, Crazyhouse and DarkChess, and
+// Hyper-FEN for Chesseract.
+func ParseBoard(rs RuleSet, s string) (Board, error) {
+	if _, ok := rs.(Boring2D); ok {
+		return ParseFEN(s)
+	} else if _, ok := rs.(Chess960); ok {
+		return ParseFEN(s)
+	} else if _, ok := rs.(Crazyhouse); ok {
+		return ParseFEN(s)
+	} else if _, ok := rs.(DarkChess); ok {
+		return ParseFEN(s)
+	} else if _, ok := rs.(Chesseract); ok {
+		return ParseHyperFEN(s)
+	}
+	return Board{}, fmt.Errorf("rule set '%s' has no text notation for positions", rs)
+}
+
+// FormatBoard encodes a board in the notation appropriate for the rule set
+func FormatBoard(rs RuleSet, b Board) (string, error) {
+	if _, ok := rs.(Boring2D); ok {
+		return b.FEN(), nil
+	} else if _, ok := rs.(Chess960); ok {
+		return b.FEN(), nil
+	} else if _, ok := rs.(Crazyhouse); ok {
+		return b.FEN(), nil
+	} else if _, ok := rs.(DarkChess); ok {
+		return b.FEN(), nil
+	} else if _, ok := rs.(Chesseract); ok {
+		return b.HyperFEN(), nil
+	}
+	return "", fmt.Errorf("rule set '%s' has no text notation for positions", rs)
+}
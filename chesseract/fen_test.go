@@ -0,0 +1,112 @@
+package chesseract
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFEN(t *testing.T) {
+	start := "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+	if s := (Boring2D{}).DefaultBoard().FEN(); s != start {
+		t.Errorf("Default board is '%s'", s)
+	}
+
+	valid := []string{
+		start,
+		"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 12 40",
+		"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
+	}
+	for _, s := range valid {
+		board, err := ParseFEN(s)
+		if err != nil {
+			t.Errorf("Error parsing '%s': %v", s, err)
+			continue
+		}
+		if f := board.FEN(); f != s {
+			t.Errorf("FEN '%s' turns into '%s'", s, f)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/9/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR x KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQxq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq z9 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 0",
+	}
+	for _, s := range invalid {
+		if _, err := ParseFEN(s); err == nil {
+			t.Errorf("FEN '%s' should not parse", s)
+		}
+	}
+
+	// Moves update the counters
+	rs := Boring2D{}
+	board, _ := ParseFEN(valid[1])
+	board, err := rs.ApplyMove(board, Move{KNIGHT, position2D{6, 7}, position2D{5, 5}, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := board.FEN(); s != "rnbqkb1r/pppppppp/5n2/8/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 1 2" {
+		t.Errorf("Unexpected FEN '%s' after Nf6", s)
+	}
+
+	var buf bytes.Buffer
+	match := Match{RuleSet: rs, Board: board}
+	match.DebugDump(&buf, nil, DUMP_FEN)
+	if !strings.Contains(buf.String(), board.FEN()) {
+		t.Errorf("Debug dump doesn't contain the FEN")
+	}
+}
+
+func TestStartingBoard(t *testing.T) {
+	rs := Boring2D{}
+	initial, _ := ParseFEN("4k3/8/8/8/8/8/8/R3K3 b Q - 0 30")
+	match := Match{
+		RuleSet:       rs,
+		Board:         initial,
+		StartingBoard: &initial,
+	}
+
+	shuffle := []Move{
+		{KING, position2D{4, 7}, position2D{3, 7}, 0, 0},
+		{ROOK, position2D{0, 0}, position2D{0, 1}, 0, 0},
+		{KING, position2D{3, 7}, position2D{4, 7}, 0, 0},
+		{ROOK, position2D{0, 1}, position2D{0, 0}, 0, 0},
+	}
+	for i := 0; i < 2; i++ {
+		for _, move := range shuffle {
+			newBoard, err := rs.ApplyMove(match.Board, move)
+			if err != nil {
+				t.Fatalf("error applying move %s: %v", move, err)
+			}
+			match.Board = newBoard
+			match.Moves = append(match.Moves, move)
+		}
+	}
+
+	// Moving the rook forfeits castling, so the starting position only occurs once
+	if n := match.Repetitions(); n != 2 {
+		t.Errorf("Position occurred %d times; expected 2", n)
+	}
+	if match.Board.FullMoves != 33 {
+		t.Errorf("Expected 33 completed moves, got %d", match.Board.FullMoves)
+	}
+
+	buf, err := match.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Match
+	if err := decoded.UnmarshalJSON(buf); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.StartingBoard == nil || decoded.StartingBoard.FEN() != initial.FEN() {
+		t.Errorf("Starting board didn't survive encoding")
+	}
+}
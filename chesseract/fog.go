@@ -0,0 +1,99 @@
+package chesseract
+
+// A FogOfWar is a RuleSet in which players can only see part of the board
+type FogOfWar interface {
+	RuleSet
+
+	// Visible lists the positions a player of the specified colour can see
+	Visible(Board, Colour) []Position
+}
+
+// View returns the part of the board that the players of the specified
+// colours can see. If the rule set doesn't implement FogOfWar, the whole
+// board is returned.
+func View(rs RuleSet, board Board, colours ...Colour) Board {
+	fow, ok := rs.(FogOfWar)
+	if !ok {
+		return board
+	}
+
+	visible := make(map[string]bool)
+	for _, c := range colours {
+		for _, pos := range fow.Visible(board, c) {
+			visible[pos.String()] = true
+		}
+	}
+	isVisible := func(pos Position) bool {
+		return pos != nil && visible[pos.String()]
+	}
+
+	rv := Board{
+		Turn:          board.Turn,
+		HalfMoveClock: board.HalfMoveClock,
+		FullMoves:     board.FullMoves,
+		Partial:       true,
+	}
+	for _, p := range board.Pieces {
+		if isVisible(p.Position) {
+			rv.Pieces = append(rv.Pieces, p)
+		}
+	}
+	for _, r := range board.Castling {
+		if isVisible(r) {
+			rv.Castling = append(rv.Castling, r)
+		}
+	}
+	if isVisible(board.EnPassant.Target) && isVisible(board.EnPassant.Pawn) {
+		rv.EnPassant = board.EnPassant
+	}
+	for _, p := range board.Reserve {
+		for _, c := range colours {
+			if p.Colour == c {
+				rv.Reserve = append(rv.Reserve, p)
+			}
+		}
+	}
+	rv.index = newBoardIndex(rv.Pieces)
+
+	return rv
+}
+
+// View returns the match as seen by the players of the specified colours. The
+// board only contains what they can see, and the moves of the other players
+// are hidden, apart from the time at which they occurred. If the rule set
+// doesn't implement FogOfWar, the match is returned as is.
+func (m Match) View(colours ...Colour) Match {
+	if _, ok := m.RuleSet.(FogOfWar); !ok {
+		return m
+	}
+
+	rv := m
+	rv.Board = View(m.RuleSet, m.Board, colours...)
+
+	// Replay the match to find out who made each move
+	rv.Moves = make([]Move, len(m.Moves))
+	board := m.InitialBoard()
+	replayable := true
+	for i, mv := range m.Moves {
+		mine := false
+		for _, c := range colours {
+			mine = mine || (replayable && board.Turn == c)
+		}
+		if mine {
+			rv.Moves[i] = mv
+		} else {
+			rv.Moves[i] = Move{Time: mv.Time}
+		}
+
+		if replayable {
+			newBoard, err := m.RuleSet.ApplyMove(board, mv)
+			if err == nil {
+				board = newBoard
+			} else {
+				replayable = false
+			}
+		}
+	}
+
+	return rv
+}
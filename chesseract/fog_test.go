@@ -0,0 +1,100 @@
+package chesseract
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDarkChessView(t *testing.T) {
+	rs := DarkChess{}
+	board := rs.DefaultBoard()
+
+	// White sees its own pieces, and the third and fourth rank
+	view := View(rs, board, WHITE)
+	if !view.Partial {
+		t.Errorf("View should be marked as partial")
+	}
+	if len(view.Pieces) != 16 {
+		t.Errorf("Expected 16 visible pieces; got %d", len(view.Pieces))
+	}
+	for _, p := range view.Pieces {
+		if p.Colour != WHITE {
+			t.Errorf("Black piece %v shouldn't be visible", p)
+		}
+	}
+	visible := make(map[string]bool)
+	for _, pos := range rs.Visible(board, WHITE) {
+		visible[pos.String()] = true
+	}
+	if len(visible) != 32 {
+		t.Errorf("Expected 32 visible positions; got %d", len(visible))
+	}
+
+	// Both players together see everything
+	if view := View(rs, board, WHITE, BLACK); len(view.Pieces) != 32 {
+		t.Errorf("Expected all pieces to be visible; got %d", len(view.Pieces))
+	}
+
+	// Regular rule sets don't hide anything
+	if view := View(Boring2D{}, board, WHITE); view.Partial || len(view.Pieces) != 32 {
+		t.Errorf("Boring2D shouldn't hide pieces")
+	}
+
+	// Pieces come into view once they can be captured
+	board, err := ParseFEN("4k3/8/8/3p4/8/8/8/3QK3 w - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	view = View(rs, board, WHITE)
+	if pc, ok := view.At(position2D{3, 4}); !ok || pc.Colour != BLACK {
+		t.Errorf("The pawn on d5 should be visible")
+	}
+	if _, ok := view.At(position2D{4, 7}); ok {
+		t.Errorf("The black king shouldn't be visible")
+	}
+}
+
+func TestDarkChessMatchView(t *testing.T) {
+	rs := DarkChess{}
+	match := Match{
+		RuleSet: rs,
+		Board:   rs.DefaultBoard(),
+	}
+	for _, s := range []string{"e4", "d5", "exd5"} {
+		move, err := ParseSAN(rs, match.Board, s)
+		if err != nil {
+			t.Fatalf("error parsing '%s': %v", s, err)
+		}
+		match.Board, err = rs.ApplyMove(match.Board, move)
+		if err != nil {
+			t.Fatalf("error applying move '%s': %v", s, err)
+		}
+		match.Moves = append(match.Moves, move)
+	}
+
+	view := match.View(BLACK)
+	if !view.Moves[0].IsHidden() || view.Moves[1].IsHidden() || !view.Moves[2].IsHidden() {
+		t.Errorf("Only black's moves should be visible: %v", view.Moves)
+	}
+	if view.Result() != nil {
+		t.Errorf("A partial board shouldn't have a result")
+	}
+	if len(match.Moves) != 3 || match.Moves[0].IsHidden() {
+		t.Errorf("Viewing a match shouldn't change the original")
+	}
+
+	b, err := json.Marshal(view)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Match
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Moves) != 3 || !decoded.Moves[0].IsHidden() || decoded.Moves[1].IsHidden() {
+		t.Errorf("Hidden moves don't survive a round trip through JSON: %v", decoded.Moves)
+	}
+	if !decoded.Board.Partial || decoded.Board.Hash() != view.Board.Hash() {
+		t.Errorf("Partial board doesn't survive a round trip through JSON")
+	}
+}
@@ -0,0 +1,300 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/thijzert/chesseract/chesseract"
+)
+
+// pgnDate is the date format used in PGN tag pairs
+const pgnDate = "2006.01.02"
+
+// pgnResult converts a game result into the notation used in PGN
+func (g Game) pgnResult() string {
+	if len(g.Result) != 2 {
+		return "*"
+	}
+	if g.Result[0] > g.Result[1] {
+		return "1-0"
+	} else if g.Result[0] < g.Result[1] {
+		return "0-1"
+	}
+	return "1/2-1/2"
+}
+
+// playerName returns the name of the player playing as the specified colour
+func (g Game) playerName(c chesseract.Colour) string {
+	for _, pl := range g.Players {
+		if pl.PlayingAs == c {
+			return pl.Name
+		}
+	}
+	return "?"
+}
+
+// WritePGN writes this game in Portable Game Notation. Chesseract games use a
+// dialect in which moves are written in the 4D extension of Standard
+// Algebraic Notation, e.g. 'Nb3n1'.
+func (g Game) WritePGN(w io.Writer) error {
+	m := g.Match
+	if len(m.RuleSet.PlayerColours()) != 2 {
+		return fmt.Errorf("PGN does not support games with %d players", len(m.RuleSet.PlayerColours()))
+	}
+	board := m.InitialBoard()
+
+	tags := [][2]string{
+		{"Event", "Chesseract match"},
+		{"Site", "?"},
+		{"Date", "????.??.??"},
+		{"Round", "-"},
+		{"White", g.playerName(chesseract.WHITE)},
+		{"Black", g.playerName(chesseract.BLACK)},
+		{"Result", g.pgnResult()},
+	}
+	// Standard tools assume regular chess when there's no variant tag
+	if _, ok := m.RuleSet.(chesseract.Boring2D); !ok {
+		tags = append(tags, [2]string{"Variant", m.RuleSet.String()})
+	}
+	if !m.StartTime.IsZero() {
+		tags[2][1] = m.StartTime.Format(pgnDate)
+	}
+	// Chess960 games always include the starting position, for the benefit
+	// of other tools
+	_, chess960 := m.RuleSet.(chesseract.Chess960)
+	if m.StartingBoard != nil || chess960 {
+		fen, err := chesseract.FormatBoard(m.RuleSet, board)
+		if err != nil {
+			return err
+		}
+		tags = append(tags, [2]string{"SetUp", "1"}, [2]string{"FEN", fen})
+	}
+
+	for _, tag := range tags {
+		value := strings.ReplaceAll(tag[1], "\\", "\\\\")
+		value = strings.ReplaceAll(value, "\"", "\\\"")
+		if _, err := fmt.Fprintf(w, "[%s \"%s\"]\n", tag[0], value); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "\n"); err != nil {
+		return err
+	}
+
+	// Movetext, wrapped at 80 characters
+	var tokens []string
+	moveNumber := board.FullMoves + 1
+	for i, mv := range m.Moves {
+		if board.Turn == chesseract.WHITE {
+			tokens = append(tokens, fmt.Sprintf("%d.", moveNumber))
+		} else if i == 0 {
+			tokens = append(tokens, fmt.Sprintf("%d...", moveNumber))
+		}
+		tokens = append(tokens, chesseract.SAN(m.RuleSet, board, mv))
+
+		newBoard, err := m.RuleSet.ApplyMove(board, mv)
+		if err != nil {
+			return fmt.Errorf("error replaying move %d: %v", i+1, err)
+		}
+		board = newBoard
+		moveNumber = board.FullMoves + 1
+	}
+	tokens = append(tokens, g.pgnResult())
+
+	line := ""
+	for _, tok := range tokens {
+		if line != "" && len(line)+1+len(tok) > 80 {
+			if _, err := fmt.Fprintf(w, "%s\n", line); err != nil {
+				return err
+			}
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += tok
+	}
+	_, err := fmt.Fprintf(w, "%s\n", line)
+	return err
+}
+
+// ReadPGN reads a single game in Portable Game Notation, and replays its
+// moves to reconstruct the match
+func ReadPGN(r io.Reader) (Game, error) {
+	rv := Game{}
+	tags := make(map[string]string)
+
+	br := bufio.NewReader(r)
+	var movetext strings.Builder
+	for {
+		line, err := br.ReadString('\n')
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			name, value, perr := parsePGNTag(trimmed)
+			if perr != nil {
+				return rv, perr
+			}
+			tags[name] = value
+		} else if !strings.HasPrefix(trimmed, "%") {
+			movetext.WriteString(trimmed)
+			movetext.WriteByte('\n')
+		}
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return rv, err
+		}
+	}
+
+	ruleSet := "Boring2D"
+	if v, ok := tags["Variant"]; ok && v != "Standard" {
+		ruleSet = v
+	}
+	rs := chesseract.GetRuleSet(ruleSet)
+	if rs == nil {
+		return rv, fmt.Errorf("unknown rule set '%s'", ruleSet)
+	}
+	rv.Match.RuleSet = rs
+
+	if fen, ok := tags["FEN"]; ok {
+		board, err := chesseract.ParseBoard(rs, fen)
+		if err != nil {
+			return rv, err
+		}
+		rv.Match.StartingBoard = &board
+	}
+	if d, ok := tags["Date"]; ok {
+		rv.Match.StartTime, _ = time.Parse(pgnDate, d)
+	}
+
+	for _, c := range rs.PlayerColours() {
+		name := ""
+		if c == chesseract.WHITE {
+			name = tags["White"]
+		} else if c == chesseract.BLACK {
+			name = tags["Black"]
+		}
+		if name != "" && name != "?" {
+			rv.Players = append(rv.Players, MatchPlayer{
+				Player:    Player{Name: name},
+				PlayingAs: c,
+			})
+		}
+	}
+
+	rv.Match.Board = rv.Match.InitialBoard()
+	for _, tok := range pgnTokens(movetext.String()) {
+		if tok == "1-0" || tok == "0-1" || tok == "1/2-1/2" || tok == "*" {
+			continue
+		}
+
+		mv, err := chesseract.ParseSAN(rs, rv.Match.Board, tok)
+		if err != nil {
+			return rv, fmt.Errorf("move %d: %v", len(rv.Match.Moves)+1, err)
+		}
+		newBoard, err := rs.ApplyMove(rv.Match.Board, mv)
+		if err != nil {
+			return rv, fmt.Errorf("move %d: illegal move '%s'", len(rv.Match.Moves)+1, tok)
+		}
+		rv.Match.Board = newBoard
+		rv.Match.Moves = append(rv.Match.Moves, mv)
+	}
+
+	res := tags["Result"]
+	if res == "1-0" {
+		rv.Result = []float64{1, 0}
+	} else if res == "0-1" {
+		rv.Result = []float64{0, 1}
+	} else if res == "1/2-1/2" {
+		rv.Result = []float64{0.5, 0.5}
+	}
+
+	return rv, nil
+}
+
+// parsePGNTag decodes a tag pair, e.g. '[White "Alice"]'
+func parsePGNTag(s string) (string, string, error) {
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	i := strings.Index(s, " ")
+	if i < 0 {
+		return "", "", fmt.Errorf("invalid PGN tag '[%s]'", s)
+	}
+	name, value := s[:i], strings.TrimSpace(s[i+1:])
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return "", "", fmt.Errorf("invalid PGN tag '[%s]'", s)
+	}
+	value = value[1 : len(value)-1]
+	value = strings.ReplaceAll(value, "\\\"", "\"")
+	value = strings.ReplaceAll(value, "\\\\", "\\")
+	return name, value, nil
+}
+
+// pgnTokens splits PGN movetext into moves, skipping move numbers, comments,
+// annotations and variations
+func pgnTokens(s string) []string {
+	var rv []string
+	depth := 0
+	inComment := false
+	inLineComment := false
+	var cur strings.Builder
+
+	flush := func() {
+		tok := cur.String()
+		cur.Reset()
+		if tok == "" || depth > 0 || tok[0] == '$' {
+			return
+		}
+		// Remove move numbers, e.g. '12.' or '12...', possibly attached to the move
+		i := 0
+		for i < len(tok) && tok[i] >= '0' && tok[i] <= '9' {
+			i++
+		}
+		if i < len(tok) && tok[i] == '.' {
+			tok = strings.TrimLeft(tok[i:], ".")
+		}
+		if tok != "" {
+			rv = append(rv, tok)
+		}
+	}
+
+	for _, c := range s {
+		if inLineComment {
+			if c == '\n' {
+				inLineComment = false
+			}
+			continue
+		} else if inComment {
+			if c == '}' {
+				inComment = false
+			}
+			continue
+		}
+
+		if c == '{' {
+			flush()
+			inComment = true
+		} else if c == ';' {
+			flush()
+			inLineComment = true
+		} else if c == '(' {
+			flush()
+			depth++
+		} else if c == ')' {
+			flush()
+			if depth > 0 {
+				depth--
+			}
+		} else if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+			flush()
+		} else {
+			cur.WriteRune(c)
+		}
+	}
+	flush()
+
+	return rv
+}
@@ -0,0 +1,161 @@
+package game
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/thijzert/chesseract/chesseract"
+)
+
+func pgnRoundTrip(t *testing.T, g Game) Game {
+	var buf bytes.Buffer
+	if err := g.WritePGN(&buf); err != nil {
+		t.Fatalf("error writing PGN: %v", err)
+	}
+	t.Logf("PGN:\n%s", buf.String())
+
+	decoded, err := ReadPGN(&buf)
+	if err != nil {
+		t.Fatalf("error reading PGN: %v", err)
+	}
+
+	if decoded.Match.RuleSet.String() != g.Match.RuleSet.String() {
+		t.Errorf("Rule set changed to %s", decoded.Match.RuleSet)
+	}
+	if len(decoded.Match.Moves) != len(g.Match.Moves) {
+		t.Fatalf("Decoded %d moves; expected %d", len(decoded.Match.Moves), len(g.Match.Moves))
+	}
+	for i, mv := range g.Match.Moves {
+		dmv := decoded.Match.Moves[i]
+		if !mv.From.Equals(dmv.From) || !mv.To.Equals(dmv.To) || mv.Promotion != dmv.Promotion {
+			t.Errorf("Move %d changed from %s to %s", i+1, mv, dmv)
+		}
+	}
+	if decoded.Match.Board.Hash() != g.Match.Board.Hash() {
+		t.Errorf("Final position differs")
+	}
+	if len(decoded.Players) != len(g.Players) {
+		t.Errorf("Decoded %d players; expected %d", len(decoded.Players), len(g.Players))
+	}
+	return decoded
+}
+
+func playMoves(t *testing.T, g *Game, moves [][2]string) {
+	rs := g.Match.RuleSet
+	for _, m := range moves {
+		from, _ := rs.ParsePosition(m[0])
+		to, _ := rs.ParsePosition(m[1])
+		pc, _ := g.Match.Board.At(from)
+		mv := chesseract.Move{PieceType: pc.PieceType, From: from, To: to}
+		newBoard, err := rs.ApplyMove(g.Match.Board, mv)
+		if err != nil {
+			t.Fatalf("error applying move %s-%s: %v", m[0], m[1], err)
+		}
+		g.Match.Board = newBoard
+		g.Match.Moves = append(g.Match.Moves, mv)
+	}
+	g.Result = g.Match.Result()
+}
+
+func TestPGN(t *testing.T) {
+	rs := chesseract.Boring2D{}
+	g := Game{
+		Players: []MatchPlayer{
+			{Player{Name: "alice"}, chesseract.WHITE},
+			{Player{Name: "bob"}, chesseract.BLACK},
+		},
+		Match: chesseract.Match{
+			RuleSet: rs,
+			Board:   rs.DefaultBoard(),
+		},
+	}
+	playMoves(t, &g, [][2]string{{"f2", "f3"}, {"e7", "e5"}, {"g2", "g4"}, {"d8", "h4"}})
+
+	decoded := pgnRoundTrip(t, g)
+	if len(decoded.Result) != 2 || decoded.Result[0] != 0 || decoded.Result[1] != 1 {
+		t.Errorf("Unexpected result %v", decoded.Result)
+	}
+	if decoded.Players[0].Name != "alice" || decoded.Players[1].Name != "bob" {
+		t.Errorf("Unexpected players %v", decoded.Players)
+	}
+
+	// Import a game with comments, variations and annotations
+	pgn := `[Event "Test"]
+[White "Alice \"The Hammer\""]
+[Black "Bob"]
+[Result "*"]
+
+1. e2e4 {best by test} e7-e5 2. Ng1-f3 (2. Bf1-c4 Ng8-f6) Nb8-c6! $1
+3. Bf1-b5 a7-a6 ; the Morphy defence
+4. Bb5xc6 d7xc6 *
+`
+	g2, err := ReadPGN(strings.NewReader(pgn))
+	if err != nil {
+		t.Fatalf("error reading PGN: %v", err)
+	}
+	if len(g2.Match.Moves) != 8 {
+		t.Errorf("Read %d moves; expected 8", len(g2.Match.Moves))
+	}
+	if g2.Result != nil {
+		t.Errorf("Unexpected result %v", g2.Result)
+	}
+	if g2.Players[0].Name != "Alice \"The Hammer\"" {
+		t.Errorf("Unexpected name '%s'", g2.Players[0].Name)
+	}
+
+	if _, err := ReadPGN(strings.NewReader("1. e2-e5 *")); err == nil {
+		t.Errorf("Illegal moves should be rejected")
+	}
+}
+
+func TestPGNStartingPosition(t *testing.T) {
+	rs := chesseract.Boring2D{}
+	board, err := chesseract.ParseFEN("4k3/P7/8/8/8/8/8/4K3 b - - 0 40")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := Game{
+		Match: chesseract.Match{
+			RuleSet:       rs,
+			Board:         board,
+			StartingBoard: &board,
+		},
+	}
+	playMoves(t, &g, [][2]string{{"e8", "d7"}})
+
+	a7, _ := rs.ParsePosition("a7")
+	a8, _ := rs.ParsePosition("a8")
+	mv := chesseract.Move{PieceType: chesseract.PAWN, From: a7, To: a8, Promotion: chesseract.QUEEN}
+	g.Match.Board, err = rs.ApplyMove(g.Match.Board, mv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Match.Moves = append(g.Match.Moves, mv)
+
+	decoded := pgnRoundTrip(t, g)
+	if decoded.Match.StartingBoard == nil || decoded.Match.StartingBoard.FEN() != board.FEN() {
+		t.Errorf("Starting position didn't survive")
+	}
+}
+
+func TestPGNChesseract(t *testing.T) {
+	rs := chesseract.Chesseract{}
+	g := Game{
+		Players: []MatchPlayer{
+			{Player{Name: "alice"}, chesseract.WHITE},
+			{Player{Name: "bob"}, chesseract.BLACK},
+		},
+		Match: chesseract.Match{
+			RuleSet: rs,
+			Board:   rs.DefaultBoard(),
+		},
+	}
+	playMoves(t, &g, [][2]string{
+		{"c3o1", "c4o1"}, {"c4p6", "c3p6"},
+		{"c1n1", "b3n1"}, {"d6o5", "d6o4"},
+		{"c4o1", "c5o1"},
+	})
+
+	pgnRoundTrip(t, g)
+}
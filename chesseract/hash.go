@@ -0,0 +1,95 @@
+package chesseract
+
+import "hash/fnv"
+
+// Zobrist keys are derived from a fixed seed, so that hashes are stable
+// across processes. This lets a client and a server compare their boards.
+const (
+	zobristPieceSeed     uint64 = 0x6368657373657261
+	zobristTurnSeed      uint64 = 0x637421a5c3b0e9d1
+	zobristCastlingSeed  uint64 = 0x2f9e5d07b4c18a63
+	zobristEnPassantSeed uint64 = 0x91c3e4a6d7f05b28
+	zobristReserveSeed   uint64 = 0x5a17c0e3b8d24f96
+)
+
+// zobristSquares is the number of squares on the largest board that has keys
+// of its own. A 6×6×6×6 Chesseract board has 1296 squares.
+const zobristSquares = 1296
+
+// splitmix64 scrambles a 64-bit number
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// squareIndex converts a position to a number that identifies it on the board
+func squareIndex(pos Position) uint64 {
+	if p, ok := pos.(position2D); ok {
+		return uint64(p[0] + 8*p[1])
+	} else if p, ok := pos.(position3D); ok {
+		return uint64(p[0] + 5*p[1] + 25*p[2])
+	} else if p, ok := pos.(position4D); ok {
+		return uint64(p[0] + 6*p[1] + 36*p[2] + 216*p[3])
+	} else if p, ok := pos.(positionN); ok {
+		index, size := 0, 1
+		for i := 0; i < p.dims; i++ {
+			index += size * p.c[i]
+			size *= p.size
+		}
+		if index < zobristSquares {
+			return uint64(index)
+		}
+	}
+
+	// Fall back to hashing the position's string representation
+	h := fnv.New64a()
+	h.Write([]byte(pos.String()))
+	return zobristSquares + h.Sum64()%(1<<32)
+}
+
+// zobristPiece returns the key for a piece of this type and colour on this position
+func zobristPiece(p Piece) uint64 {
+	slot := uint64(p.PieceType&0x7)<<3 | uint64(p.Colour&0x7)
+	return splitmix64(zobristPieceSeed ^ (squareIndex(p.Position)<<6 | slot))
+}
+
+// piecesHash returns the hash of all pieces on the board. It uses the value
+// maintained while moving pieces if possible, and calculates it from scratch
+// otherwise.
+func (b Board) piecesHash() uint64 {
+	if b.pieceHash != 0 {
+		return b.pieceHash
+	}
+
+	// Start with a non-zero value, so that a zero hash means 'not calculated'
+	rv := zobristPieceSeed
+	for _, p := range b.Pieces {
+		rv ^= zobristPiece(p)
+	}
+	return rv
+}
+
+// Hash returns a Zobrist hash of the position on this board, including whose
+// turn it is, any castling and en passant rights, and the pieces in reserve.
+func (b Board) Hash() uint64 {
+	rv := b.piecesHash()
+	rv ^= splitmix64(zobristTurnSeed ^ uint64(b.Turn))
+	for _, pos := range b.Castling {
+		rv ^= splitmix64(zobristCastlingSeed ^ squareIndex(pos))
+	}
+	if b.EnPassant.Target != nil {
+		rv ^= splitmix64(zobristEnPassantSeed ^ squareIndex(b.EnPassant.Target))
+	}
+
+	// Each copy of a piece in reserve gets a key of its own, so that the
+	// order of the reserve doesn't matter
+	count := make(map[uint64]uint64)
+	for _, p := range b.Reserve {
+		slot := uint64(p.PieceType&0x7)<<3 | uint64(p.Colour&0x7)
+		rv ^= splitmix64(zobristReserveSeed ^ (count[slot]<<6 | slot))
+		count[slot]++
+	}
+	return rv
+}
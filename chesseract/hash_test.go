@@ -0,0 +1,89 @@
+package chesseract
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// freshHash calculates the hash of a board from scratch
+func freshHash(b Board) uint64 {
+	b.pieceHash = 0
+	return b.Hash()
+}
+
+func TestIncrementalHash(t *testing.T) {
+	for _, rs := range []RuleSet{Boring2D{}, Chesseract{}} {
+		rng := rand.New(rand.NewSource(1))
+
+		board := rs.DefaultBoard()
+		for i := 0; i < 60; i++ {
+			moves := LegalMoves(rs, board)
+			if len(moves) == 0 {
+				break
+			}
+			move := moves[rng.Intn(len(moves))]
+
+			newBoard, err := rs.ApplyMove(board, move)
+			if err != nil {
+				t.Fatalf("error applying move %s: %v", move, err)
+			}
+			if newBoard.Hash() != freshHash(newBoard) {
+				t.Errorf("%s: incremental hash after %s differs from a fresh one", rs, move)
+			}
+			if newBoard.Hash() == board.Hash() {
+				t.Errorf("%s: hash didn't change after %s", rs, move)
+			}
+			board = newBoard
+		}
+	}
+
+	// Castling, en passant and promotion
+	rs := Boring2D{}
+	board, _ := ParseFEN("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	for _, move := range LegalMoves(rs, board) {
+		newBoard, _ := rs.ApplyMove(board, move)
+		for _, reply := range LegalMoves(rs, newBoard) {
+			b, _ := rs.ApplyMove(newBoard, reply)
+			if b.Hash() != freshHash(b) {
+				t.Errorf("Incremental hash after %s, %s differs from a fresh one", move, reply)
+			}
+		}
+	}
+}
+
+func TestHashTransposition(t *testing.T) {
+	rs := Boring2D{}
+	apply := func(b Board, moves ...Move) Board {
+		for _, m := range moves {
+			var err error
+			b, err = rs.ApplyMove(b, m)
+			if err != nil {
+				t.Fatalf("error applying move %s: %v", m, err)
+			}
+		}
+		return b
+	}
+
+	nf3 := Move{KNIGHT, position2D{6, 0}, position2D{5, 2}, 0, 0}
+	nc3 := Move{KNIGHT, position2D{1, 0}, position2D{2, 2}, 0, 0}
+	nf6 := Move{KNIGHT, position2D{6, 7}, position2D{5, 5}, 0, 0}
+	nc6 := Move{KNIGHT, position2D{1, 7}, position2D{2, 5}, 0, 0}
+
+	a := apply(rs.DefaultBoard(), nf3, nf6, nc3, nc6)
+	b := apply(rs.DefaultBoard(), nc3, nc6, nf3, nf6)
+	if a.Hash() != b.Hash() {
+		t.Errorf("Transposed positions have different hashes")
+	}
+
+	c := a
+	c.Turn = BLACK
+	if a.Hash() == c.Hash() {
+		t.Errorf("Hash doesn't depend on whose turn it is")
+	}
+
+	d := a
+	d.Castling = d.Castling[1:]
+	if a.Hash() == d.Hash() {
+		t.Errorf("Hash doesn't depend on castling rights")
+	}
+}
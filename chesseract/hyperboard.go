@@ -202,12 +202,332 @@ func (Chesseract) ParsePosition(s string) (Position, error) {
 	return rv, nil
 }
 
+// CanMove tests whether a piece can move to the specified new position on the board.
+// Note: this only tests movement rules; the check check is performed elsewhere.
 func (Chesseract) CanMove(board Board, piece Piece, pos Position) bool {
-	// TODO
-	return false
+	var oldPos, newPos position4D
+	var ok bool
+	if oldPos, ok = piece.Position.(position4D); !ok {
+		return false
+	}
+	if newPos, ok = pos.(position4D); !ok {
+		return false
+	}
+
+	// Check board boundaries
+	for _, c := range newPos {
+		if c < 0 || c >= 6 {
+			return false
+		}
+	}
+
+	// Pieces have to move
+	var d position4D
+	moved := 0
+	for i := range d {
+		d[i] = newPos[i] - oldPos[i]
+		if d[i] != 0 {
+			moved++
+		}
+	}
+	if moved == 0 {
+		return false
+	}
+
+	capture := false
+
+	// You can't capture your own pieces
+	if op, ok := board.At(newPos); ok {
+		if op.Colour == piece.Colour {
+			return false
+		} else {
+			capture = true
+		}
+	}
+
+	if piece.PieceType == KING {
+		// TODO: castling
+
+		// Move one square in any direction
+		for _, c := range d {
+			if c*c > 1 {
+				return false
+			}
+		}
+
+		return true
+	} else if piece.PieceType == QUEEN {
+		// Diagonal or straight
+		if moved != 1 && !isDiagonal4d(d) {
+			return false
+		}
+	} else if piece.PieceType == BISHOP {
+		// Diagonal only
+		if !isDiagonal4d(d) {
+			return false
+		}
+	} else if piece.PieceType == KNIGHT {
+		// Still horsin' around, but now in two of four dimensions
+		if moved != 2 {
+			return false
+		}
+		one, two := 0, 0
+		for _, c := range d {
+			if c*c == 1 {
+				one++
+			} else if c*c == 4 {
+				two++
+			}
+		}
+		return one == 1 && two == 1
+	} else if piece.PieceType == ROOK {
+		// Straight only
+		if moved != 1 {
+			return false
+		}
+	} else if piece.PieceType == PAWN {
+		// White pawns advance along the y and w axes; black pawns go the other way
+		dy, dw := pawnDirections4d(piece.Colour)
+
+		// A pawn advances along exactly one of the forward axes
+		fwd := 0
+		for _, i := range []int{1, 3} {
+			if d[i] != 0 {
+				fwd++
+			}
+		}
+		if fwd != 1 || d[1]*dy < 0 || d[3]*dw < 0 {
+			return false
+		}
+		steps := d[1]*dy + d[3]*dw
+
+		if capture || board.EnPassant.canCapture(board, piece.Colour, newPos) {
+			// Capture one square forward, and one square sideways along x or z
+			return steps == 1 && moved == 2 && d[0]*d[0]+d[2]*d[2] == 1
+		} else {
+			if moved != 1 {
+				return false
+			} else if steps == 1 {
+				return true
+			} else if steps == 2 {
+				if !inHomeZone4d(piece.Colour, oldPos) {
+					return false
+				}
+				// Check trajectory below
+			} else {
+				return false
+			}
+		}
+	} else {
+		// Unknown piece
+		return false
+	}
+
+	// Check the trajectory in between
+	v, r := normalise4d(d)
+	for i := 1; i < r; i++ {
+		p := position4D{oldPos[0] + i*v[0], oldPos[1] + i*v[1], oldPos[2] + i*v[2], oldPos[3] + i*v[3]}
+		if _, ok := board.At(p); ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LegalMoves returns all legal moves for the player whose turn it is
+func (rs Chesseract) LegalMoves(board Board) []Move {
+	return legalMoves(rs, board, func(p Piece) []Position {
+		return rs.targets(board, p)
+	})
+}
+
+var straight4D, diagonal4D, knight4D, king4D = hyperVectors()
+
+// hyperVectors computes the unit vectors in every direction each piece type can move in
+func hyperVectors() (straight, diagonal, knight, king []position4D) {
+	var d position4D
+	for d[0] = -2; d[0] <= 2; d[0]++ {
+		for d[1] = -2; d[1] <= 2; d[1]++ {
+			for d[2] = -2; d[2] <= 2; d[2]++ {
+				for d[3] = -2; d[3] <= 2; d[3]++ {
+					ones, twos := 0, 0
+					for _, c := range d {
+						if c*c == 1 {
+							ones++
+						} else if c*c == 4 {
+							twos++
+						}
+					}
+					if twos == 0 && ones > 0 {
+						king = append(king, d)
+					}
+					if twos == 0 && ones == 1 {
+						straight = append(straight, d)
+					} else if twos == 0 && ones == 2 {
+						diagonal = append(diagonal, d)
+					} else if twos == 1 && ones == 1 {
+						knight = append(knight, d)
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// targets lists the positions a piece could possibly move to. The finer
+// points of the movement rules are left to CanMove.
+func (Chesseract) targets(board Board, piece Piece) []Position {
+	pos, ok := piece.Position.(position4D)
+	if !ok {
+		return nil
+	}
+
+	var rv []Position
+	add := func(p, v position4D, n int) position4D {
+		for i := range p {
+			p[i] += n * v[i]
+		}
+		return p
+	}
+	onBoard := func(p position4D) bool {
+		for _, c := range p {
+			if c < 0 || c >= 6 {
+				return false
+			}
+		}
+		return true
+	}
+	jump := func(vectors []position4D) {
+		for _, v := range vectors {
+			if p := add(pos, v, 1); onBoard(p) {
+				rv = append(rv, p)
+			}
+		}
+	}
+	slide := func(vectors []position4D) {
+		for _, v := range vectors {
+			for p := add(pos, v, 1); onBoard(p); p = add(p, v, 1) {
+				rv = append(rv, p)
+				if _, ok := board.At(p); ok {
+					break
+				}
+			}
+		}
+	}
+
+	if piece.PieceType == KING {
+		jump(king4D)
+	} else if piece.PieceType == QUEEN {
+		slide(straight4D)
+		slide(diagonal4D)
+	} else if piece.PieceType == BISHOP {
+		slide(diagonal4D)
+	} else if piece.PieceType == KNIGHT {
+		jump(knight4D)
+	} else if piece.PieceType == ROOK {
+		slide(straight4D)
+	} else if piece.PieceType == PAWN {
+		dy, dw := pawnDirections4d(piece.Colour)
+		dirs := position4D{0, dy, 0, dw}
+		for _, fwd := range []int{1, 3} {
+			var v position4D
+			v[fwd] = dirs[fwd]
+			jump([]position4D{v, add(v, v, 1)})
+			for _, side := range []int{0, 2} {
+				for _, s := range []int{-1, 1} {
+					c := v
+					c[side] = s
+					jump([]position4D{c})
+				}
+			}
+		}
+	}
+
+	return rv
+}
+
+// isDiagonal4d tests if a displacement lies on a diagonal spanning exactly two axes
+func isDiagonal4d(d position4D) bool {
+	r, n := 0, 0
+	for _, c := range d {
+		if c == 0 {
+			continue
+		}
+		if c < 0 {
+			c = -c
+		}
+		if r != 0 && c != r {
+			return false
+		}
+		r = c
+		n++
+	}
+	return n == 2
+}
+
+// pawnDirections4d returns the directions in which a player's pawns advance
+// along the y and w axes. Each player starts in a different corner of the
+// y-w plane, and advances towards the opposite one.
+func pawnDirections4d(c Colour) (dy, dw int) {
+	if c == BLACK {
+		return -1, -1
+	} else if c == RED {
+		return -1, 1
+	} else if c == BLUE {
+		return 1, -1
+	}
+	return 1, 1
+}
+
+// homeCorner4d returns the y and w coordinates of a player's starting corner
+func homeCorner4d(c Colour) (y, w int) {
+	dy, dw := pawnDirections4d(c)
+	if dy < 0 {
+		y = 5
+	}
+	if dw < 0 {
+		w = 5
+	}
+	return
+}
+
+// inHomeZone4d tests whether a pawn is still in its own hyper-corner, where it
+// is allowed to advance two squares at once. This zone coincides with the
+// pawns' starting positions on the default board.
+func inHomeZone4d(c Colour, p position4D) bool {
+	y, w := homeCorner4d(c)
+	dy, dw := pawnDirections4d(c)
+	return (p[1]-y)*dy+(p[3]-w)*dw <= 2
+}
+
+func normalise4d(d position4D) (v position4D, r int) {
+	for i, c := range d {
+		if c < 0 {
+			v[i] = -1
+			r = -1 * c
+		} else if c > 0 {
+			v[i] = 1
+			r = c
+		}
+	}
+	return
 }
 
 func (rs Chesseract) ApplyMove(board Board, move Move) (Board, error) {
+	newBoard, err := rs.applyMove(board, move)
+	if err != nil {
+		return Board{}, err
+	}
+
+	return newBoard.nextTurn(rs.PlayerColours()), nil
+}
+
+// applyMove performs a move on the board without passing the turn to the next
+// player. The movement rules don't depend on the number of players, so this is
+// shared with the four-player version.
+func (rs Chesseract) applyMove(board Board, move Move) (Board, error) {
 	piece, ok := board.At(move.From)
 	if !ok {
 		return Board{}, errIllegalMove
@@ -219,16 +539,39 @@ func (rs Chesseract) ApplyMove(board Board, move Move) (Board, error) {
 
 	newBoard := board.movePiece(move)
 
-	// TODO: pawn promotion
-	// TODO: castling
+	if piece.PieceType == PAWN {
+		from, to := move.From.(position4D), move.To.(position4D)
+		if board.EnPassant.canCapture(board, piece.Colour, to) {
+			newBoard = newBoard.removePiece(board.EnPassant.Pawn)
+		} else if dy, dw := to[1]-from[1], to[3]-from[3]; dy*dy == 4 || dw*dw == 4 {
+			newBoard.EnPassant = EnPassant{
+				Target: position4D{from[0], from[1] + dy/2, from[2], from[3] + dw/2},
+				Pawn:   to,
+			}
+		}
+	}
+
+	// Pawns reaching the opposite hyper-corner get promoted
+	to := move.To.(position4D)
+	if y, w := homeCorner4d(piece.Colour); piece.PieceType == PAWN && to[1] == 5-y && to[3] == 5-w {
+		if !validPromotion(move.Promotion) {
+			return Board{}, errIllegalMove
+		}
+		newBoard = newBoard.promote(move.To, move.Promotion)
+	} else if move.Promotion != 0 {
+		return Board{}, errIllegalMove
+	}
 
-	// TODO: check if this results in the player being in check. Reject with errIllegalMove if it does.
+	// TODO: castling
 
-	if newBoard.Turn == BLACK {
-		newBoard.Turn = WHITE
-	} else {
-		newBoard.Turn = BLACK
+	if inCheck(rs, newBoard, piece.Colour) {
+		return Board{}, errIllegalMove
 	}
 
 	return newBoard, nil
 }
+
+// Status determines whether the player whose turn it is is in check, checkmate, or stalemate
+func (rs Chesseract) Status(board Board) Status {
+	return gameStatus(rs, board)
+}
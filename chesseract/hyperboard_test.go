@@ -0,0 +1,325 @@
+package chesseract
+
+import (
+	"testing"
+)
+
+func TestHyperPositionParser(t *testing.T) {
+	rs := Chesseract{}
+	invalidValues := []string{
+		"",
+		"e2",
+		"a1m1a1",
+		"g1m1",
+		"a7m1",
+		"a1s1",
+		"a1l1",
+		"a1m0",
+	}
+	for _, s := range invalidValues {
+		p, err := rs.ParsePosition(s)
+		if err == nil {
+			t.Logf("String '%s' decodes into '%s' - not good", s, p)
+			t.Fail()
+		}
+	}
+
+	// Test every valid value
+	for _, p := range rs.AllPositions() {
+		q, err := rs.ParsePosition(p.String())
+		if err != nil {
+			t.Logf("Error parsing position '%s': %v", p, err)
+			t.Fail()
+		} else if !p.Equals(q) {
+			t.Logf("Position '%s' turns into '%s'", p, q)
+			t.Fail()
+		}
+	}
+}
+
+func TestChesseractDefaultBoard(t *testing.T) {
+	rs := Chesseract{}
+	board := rs.DefaultBoard()
+
+	total := 0
+	black := 0
+	white := 0
+	pawns := 0
+	kings := 0
+
+	for _, p := range rs.AllPositions() {
+		if pc, ok := board.At(p); ok {
+			total++
+			if pc.Colour == BLACK {
+				black++
+			} else if pc.Colour == WHITE {
+				white++
+			}
+			if pc.PieceType == PAWN {
+				pawns++
+			} else if pc.PieceType == KING {
+				kings++
+			}
+		}
+	}
+
+	if total != 108 || black != 54 || white != 54 || pawns != 76 || kings != 2 {
+		t.Logf("Something fucky is going on with this default board")
+		t.Fail()
+	}
+
+	if st := rs.Status(board); st != NORMAL {
+		t.Errorf("Unexpected status '%s' for the default board", st)
+	}
+}
+
+func TestHyperMovementRules(t *testing.T) {
+	rs := Chesseract{}
+
+	type testCase struct {
+		PieceIndex               int
+		ExpectedReachableSquares int
+	}
+	type testSuite struct {
+		Board Board
+		Cases []testCase
+	}
+
+	suite := []testSuite{
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{ROOK, WHITE, position4D{2, 2, 2, 2}},
+				},
+			},
+			Cases: []testCase{
+				{0, 20},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{BISHOP, WHITE, position4D{2, 2, 2, 2}},
+				},
+			},
+			Cases: []testCase{
+				{0, 54},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{QUEEN, BLACK, position4D{2, 2, 2, 2}},
+				},
+			},
+			Cases: []testCase{
+				{0, 74},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{KING, WHITE, position4D{2, 2, 2, 2}},
+					{KING, BLACK, position4D{0, 5, 0, 5}},
+				},
+			},
+			Cases: []testCase{
+				{0, 80},
+				{1, 15},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{KNIGHT, WHITE, position4D{2, 2, 2, 2}},
+					{KNIGHT, WHITE, position4D{0, 0, 0, 0}},
+					{KNIGHT, BLACK, position4D{2, 1, 0, 0}},
+				},
+			},
+			Cases: []testCase{
+				{0, 48},
+				{1, 12},
+				{2, 22},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{ROOK, WHITE, position4D{0, 0, 0, 0}},
+					{PAWN, WHITE, position4D{2, 0, 0, 0}},
+					{PAWN, BLACK, position4D{0, 0, 0, 3}},
+				},
+			},
+			Cases: []testCase{
+				{0, 14},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{BISHOP, BLACK, position4D{0, 0, 0, 0}},
+					{PAWN, BLACK, position4D{2, 2, 0, 0}},
+					{PAWN, WHITE, position4D{0, 0, 3, 3}},
+				},
+			},
+			Cases: []testCase{
+				{0, 24},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{PAWN, WHITE, position4D{2, 1, 2, 0}},
+					{PAWN, BLACK, position4D{2, 4, 2, 5}},
+					{PAWN, WHITE, position4D{2, 2, 2, 2}},
+					{PAWN, BLACK, position4D{3, 3, 2, 2}},
+					{PAWN, BLACK, position4D{2, 2, 1, 3}},
+					{PAWN, BLACK, position4D{2, 3, 2, 2}},
+					{PAWN, WHITE, position4D{1, 3, 2, 2}},
+					{PAWN, WHITE, position4D{4, 0, 4, 0}},
+					{ROOK, BLACK, position4D{4, 1, 4, 0}},
+				},
+			},
+			Cases: []testCase{
+				{0, 4},
+				{1, 4},
+				{2, 3},
+				{7, 2},
+			},
+		},
+	}
+
+	for _, ts := range suite {
+		for _, tc := range ts.Cases {
+			hl := []Position{}
+			piece := ts.Board.Pieces[tc.PieceIndex]
+			for _, p := range rs.AllPositions() {
+				if rs.CanMove(ts.Board, piece, p) {
+					hl = append(hl, p)
+				}
+			}
+			if len(hl) == tc.ExpectedReachableSquares {
+				t.Logf("Piece at %s can move to %d squares", piece.Position, len(hl))
+			} else {
+				t.Logf("Expected piece at %s to be able to move to %d squares, but measured %d", piece.Position, tc.ExpectedReachableSquares, len(hl))
+				for _, p := range hl {
+					t.Logf("    %s", p)
+				}
+				t.Fail()
+			}
+		}
+	}
+}
+
+func TestHyperMatch(t *testing.T) {
+	type moov struct {
+		From, To string
+	}
+	moves := []moov{
+		{"c3o1", "c4o1"}, {"c4p6", "c3p6"},
+		{"c1n1", "b3n1"}, {"d6o5", "d6o4"},
+		{"c4o1", "c5o1"},
+	}
+
+	rs := Chesseract{}
+	match := Match{
+		RuleSet: rs,
+		Board:   rs.DefaultBoard(),
+	}
+	for _, m := range moves {
+		from, err := rs.ParsePosition(m.From)
+		if err != nil {
+			t.Logf("error parsing '%s': %v", m.From, err)
+			t.Fail()
+			break
+		}
+		piece, _ := match.Board.At(from)
+		to, err := rs.ParsePosition(m.To)
+		if err != nil {
+			t.Logf("error parsing '%s': %v", m.To, err)
+			t.Fail()
+			break
+		}
+
+		move := Move{piece.PieceType, from, to, 0, 0}
+		newBoard, err := rs.ApplyMove(match.Board, move)
+		if err != nil {
+			t.Logf("applying move '%s'-'%s': %v", m.From, m.To, err)
+			t.Fail()
+			break
+		}
+
+		match.Moves = append(match.Moves, move)
+		match.Board = newBoard
+	}
+
+	logMatch(t, match, nil)
+
+	_, err := rs.ApplyMove(match.Board, Move{ROOK, position4D{2, 0, 0, 0}, position4D{2, 0, 2, 0}, 0, 0})
+	if err == nil {
+		t.Logf("Rooks can't capture their own queen")
+		t.Fail()
+	}
+	_, err = rs.ApplyMove(match.Board, Move{KNIGHT, position4D{3, 0, 1, 0}, position4D{4, 1, 1, 0}, 0, 0})
+	if err == nil {
+		t.Logf("The knight is not a bishop")
+		t.Fail()
+	}
+}
+
+func TestHyperEnPassant(t *testing.T) {
+	rs := Chesseract{}
+	board := Board{
+		Pieces: []Piece{
+			{PAWN, WHITE, position4D{2, 1, 2, 0}},
+			{PAWN, BLACK, position4D{3, 3, 2, 0}},
+		},
+		Turn: WHITE,
+	}
+
+	board, err := rs.ApplyMove(board, Move{PAWN, position4D{2, 1, 2, 0}, position4D{2, 3, 2, 0}, 0, 0})
+	if err != nil {
+		t.Fatalf("error advancing pawn: %v", err)
+	}
+	if board.EnPassant.Target == nil || !board.EnPassant.Target.Equals(position4D{2, 2, 2, 0}) {
+		t.Errorf("Unexpected en passant target %v", board.EnPassant.Target)
+	}
+
+	board, err = rs.ApplyMove(board, Move{PAWN, position4D{3, 3, 2, 0}, position4D{2, 2, 2, 0}, 0, 0})
+	if err != nil {
+		t.Fatalf("error capturing en passant: %v", err)
+	}
+	if len(board.Pieces) != 1 {
+		t.Errorf("Expected the white pawn to be captured; %d pieces remain", len(board.Pieces))
+	}
+}
+
+func TestHyperPromotion(t *testing.T) {
+	rs := Chesseract{}
+	board := Board{
+		Pieces: []Piece{
+			{PAWN, WHITE, position4D{2, 5, 2, 4}},
+			{PAWN, BLACK, position4D{3, 1, 3, 1}},
+		},
+		Turn: WHITE,
+	}
+
+	if _, err := rs.ApplyMove(board, Move{PAWN, position4D{2, 5, 2, 4}, position4D{2, 5, 2, 5}, 0, 0}); err == nil {
+		t.Errorf("A pawn reaching the far corner must be promoted")
+	}
+	newBoard, err := rs.ApplyMove(board, Move{PAWN, position4D{2, 5, 2, 4}, position4D{2, 5, 2, 5}, ROOK, 0})
+	if err != nil {
+		t.Fatalf("error promoting pawn: %v", err)
+	}
+	if pc, _ := newBoard.At(position4D{2, 5, 2, 5}); pc.PieceType != ROOK {
+		t.Errorf("Pawn turned into %s", pc.PieceType)
+	}
+
+	// Reaching the far end of just one axis isn't enough
+	if _, err := rs.ApplyMove(newBoard, Move{PAWN, position4D{3, 1, 3, 1}, position4D{3, 0, 3, 1}, QUEEN, 0}); err == nil {
+		t.Errorf("Black's pawn shouldn't be promoted yet")
+	}
+	if _, err := rs.ApplyMove(newBoard, Move{PAWN, position4D{3, 1, 3, 1}, position4D{3, 0, 3, 1}, 0, 0}); err != nil {
+		t.Errorf("error advancing black pawn: %v", err)
+	}
+}
@@ -0,0 +1,593 @@
+package chesseract
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+func init() {
+	for dims := 2; dims <= 5; dims++ {
+		for size := 4; size <= 10; size++ {
+			rs := NewHyperChess(dims, size)
+			RegisterRuleSet(rs.String(), func() RuleSet {
+				return rs
+			})
+		}
+	}
+}
+
+// maxHyperDims is the largest number of dimensions a HyperChess board can have
+const maxHyperDims = 8
+
+// A positionN represents a position on an N-dimensional hypercube board
+type positionN struct {
+	dims, size int
+	c          [maxHyperDims]int
+}
+
+// String returns the position's coordinates, alternating between letters
+// and numbers, e.g. 'c3' or 'b4a1'
+func (p positionN) String() string {
+	var sb strings.Builder
+	for i := 0; i < p.dims; i++ {
+		sb.WriteString(p.coordinate(i))
+	}
+	return sb.String()
+}
+
+// coordinate formats a single coordinate: a letter for even axes, and a
+// number for odd axes
+func (p positionN) coordinate(i int) string {
+	if i%2 == 0 {
+		return string('a' + rune(p.c[i]))
+	}
+	return strconv.Itoa(p.c[i] + 1)
+}
+
+func (p positionN) Equals(q Position) bool {
+	if q0, ok := q.(positionN); ok {
+		return p == q0
+	}
+	return false
+}
+
+func (p positionN) CellColour() Colour {
+	sum := 0
+	for _, c := range p.c {
+		sum += c
+	}
+	if sum%2 == 0 {
+		return BLACK
+	} else {
+		return WHITE
+	}
+}
+
+// WorldPosition lays out the first three dimensions like a 3D board, and
+// places copies of it side by side for each further dimension
+func (p positionN) WorldPosition() (x, y, z float32) {
+	mid := float32(p.size-1) / 2
+	x = float32(p.c[0]) - mid
+	z = float32(p.c[1]) - mid
+	if p.dims > 2 {
+		y = (float32(p.c[2]) - mid) * 3.6
+	}
+	for i := 3; i < p.dims; i++ {
+		offset := (float32(p.c[i]) - mid) * float32(p.size+3)
+		if i%2 == 1 {
+			z += offset
+		} else {
+			x += offset
+		}
+	}
+	return
+}
+
+// The HyperChess type implements chess on a hypercube board with any number
+// of dimensions and any size. Coordinates along the even axes (written as
+// letters) run sideways, and pawns advance along the odd axes (written as
+// numbers). White starts in the corner where all forward coordinates are 0.
+type HyperChess struct {
+	// Dims is the number of dimensions, from 2 to 8
+	Dims int
+
+	// Size is the length of each side of the board, from 4 to 26
+	Size int
+}
+
+// NewHyperChess creates a rule set for a board with the specified number of
+// dimensions and size
+func NewHyperChess(dims, size int) HyperChess {
+	return HyperChess{Dims: dims, Size: size}
+}
+
+func (rs HyperChess) String() string {
+	return fmt.Sprintf("Hyper%dx%d", rs.Dims, rs.Size)
+}
+
+func (HyperChess) PlayerColours() []Colour {
+	return []Colour{WHITE, BLACK}
+}
+
+// forward returns the distance of a position from a player's starting corner
+// along the forward axes
+func (rs HyperChess) forward(c Colour, p positionN) (sum int, max int) {
+	for i := 1; i < rs.Dims; i += 2 {
+		f := p.c[i]
+		if c == BLACK {
+			f = rs.Size - 1 - f
+		}
+		sum += f
+		if f > max {
+			max = f
+		}
+	}
+	return
+}
+
+// DefaultBoard sets up the initial board configuration. Each player's back
+// rank generalises to all squares in their starting corner, and is shielded
+// by a layer of pawns.
+func (rs HyperChess) DefaultBoard() Board {
+	rv := Board{
+		Turn: WHITE,
+	}
+
+	for _, c := range rs.PlayerColours() {
+		for _, pos := range rs.AllPositions() {
+			p := pos.(positionN)
+			if sum, _ := rs.forward(c, p); sum == 0 {
+				rv.Pieces = append(rv.Pieces, Piece{rs.backRankPiece(p), c, p})
+			} else if sum == 1 {
+				rv.Pieces = append(rv.Pieces, Piece{PAWN, c, p})
+			}
+		}
+	}
+
+	return rv
+}
+
+// backRankPiece determines which piece starts on a square in the back rank.
+// Each line along the first axis is lined up like a regular back rank. The
+// king and queen go in the centre line; all other lines get bishops instead.
+func (rs HyperChess) backRankPiece(p positionN) PieceType {
+	central := true
+	for i := 2; i < rs.Dims; i += 2 {
+		central = central && p.c[i] == rs.Size/2
+	}
+
+	x := p.c[0]
+	edge := x
+	if rs.Size-1-x < edge {
+		edge = rs.Size - 1 - x
+	}
+
+	if central && x == rs.Size/2 {
+		return KING
+	} else if central && x == rs.Size/2-1 {
+		return QUEEN
+	} else if edge == 0 {
+		return ROOK
+	} else if edge == 1 {
+		return KNIGHT
+	}
+	return BISHOP
+}
+
+// AllPositions returns an iterator that allows one to range over all possible positions on the board in this variant
+func (rs HyperChess) AllPositions() []Position {
+	n := 1
+	for i := 0; i < rs.Dims; i++ {
+		n *= rs.Size
+	}
+
+	rv := make([]Position, n)
+	for j := range rv {
+		p := positionN{dims: rs.Dims, size: rs.Size}
+		k := j
+		for i := 0; i < rs.Dims; i++ {
+			p.c[i] = k % rs.Size
+			k /= rs.Size
+		}
+		rv[j] = p
+	}
+	return rv
+}
+
+// ParsePosition converts a string representation into a Position of the correct type
+func (rs HyperChess) ParsePosition(s string) (Position, error) {
+	rv := positionN{dims: rs.Dims, size: rs.Size}
+	for i := 0; i < rs.Dims; i++ {
+		if len(s) == 0 {
+			return invalidPosition{}, errInvalidFormat
+		}
+		if i%2 == 0 {
+			rv.c[i] = int(s[0]) - 'a'
+			s = s[1:]
+		} else {
+			n := 0
+			for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+				n++
+			}
+			c, err := strconv.Atoi(s[:n])
+			if err != nil || s[0] == '0' {
+				return invalidPosition{}, errInvalidFormat
+			}
+			rv.c[i] = c - 1
+			s = s[n:]
+		}
+		if rv.c[i] < 0 || rv.c[i] >= rs.Size {
+			return invalidPosition{}, errInvalidFormat
+		}
+	}
+	if len(s) > 0 {
+		return invalidPosition{}, errInvalidFormat
+	}
+	return rv, nil
+}
+
+// onBoard tests if a position lies within the board's boundaries
+func (rs HyperChess) onBoard(p positionN) bool {
+	if p.dims != rs.Dims || p.size != rs.Size {
+		return false
+	}
+	for i := 0; i < rs.Dims; i++ {
+		if p.c[i] < 0 || p.c[i] >= rs.Size {
+			return false
+		}
+	}
+	return true
+}
+
+// CanMove tests whether a piece can move to the specified new position on the board.
+// Note: this only tests movement rules; the check check is performed elsewhere.
+func (rs HyperChess) CanMove(board Board, piece Piece, pos Position) bool {
+	var oldPos, newPos positionN
+	var ok bool
+	if oldPos, ok = piece.Position.(positionN); !ok {
+		return false
+	}
+	if newPos, ok = pos.(positionN); !ok {
+		return false
+	}
+
+	// Check board boundaries
+	if !rs.onBoard(oldPos) || !rs.onBoard(newPos) {
+		return false
+	}
+
+	// Pieces have to move
+	var d [maxHyperDims]int
+	moved := 0
+	for i := 0; i < rs.Dims; i++ {
+		d[i] = newPos.c[i] - oldPos.c[i]
+		if d[i] != 0 {
+			moved++
+		}
+	}
+	if moved == 0 {
+		return false
+	}
+
+	capture := false
+
+	// You can't capture your own pieces
+	if op, ok := board.At(newPos); ok {
+		if op.Colour == piece.Colour {
+			return false
+		} else {
+			capture = true
+		}
+	}
+
+	if piece.PieceType == KING {
+		// Move one square in any direction
+		for _, c := range d {
+			if c*c > 1 {
+				return false
+			}
+		}
+
+		return true
+	} else if piece.PieceType == QUEEN {
+		// Diagonal or straight
+		if moved != 1 && !isDiagonalN(d) {
+			return false
+		}
+	} else if piece.PieceType == BISHOP {
+		// Diagonal only
+		if !isDiagonalN(d) {
+			return false
+		}
+	} else if piece.PieceType == KNIGHT {
+		// One square along one axis, and two along another
+		if moved != 2 {
+			return false
+		}
+		one, two := 0, 0
+		for _, c := range d {
+			if c*c == 1 {
+				one++
+			} else if c*c == 4 {
+				two++
+			}
+		}
+		return one == 1 && two == 1
+	} else if piece.PieceType == ROOK {
+		// Straight only
+		if moved != 1 {
+			return false
+		}
+	} else if piece.PieceType == PAWN {
+		dir := 1
+		if piece.Colour == BLACK {
+			dir = -1
+		}
+
+		// A pawn advances along exactly one of the forward axes, and may
+		// only move sideways while capturing
+		fwd, steps, sideways := 0, 0, 0
+		for i := 0; i < rs.Dims; i++ {
+			if i%2 == 0 {
+				sideways += d[i] * d[i]
+			} else if d[i] != 0 {
+				fwd++
+				steps = d[i] * dir
+			}
+		}
+		if fwd != 1 || steps <= 0 {
+			return false
+		}
+
+		if capture || board.EnPassant.canCapture(board, piece.Colour, newPos) {
+			// Capture one square forward, and one square sideways
+			return steps == 1 && sideways == 1
+		} else {
+			if sideways != 0 {
+				return false
+			} else if steps == 1 {
+				return true
+			} else if steps == 2 {
+				if sum, _ := rs.forward(piece.Colour, oldPos); sum > 1 {
+					return false
+				}
+				// Check trajectory below
+			} else {
+				return false
+			}
+		}
+	} else {
+		// Unknown piece
+		return false
+	}
+
+	// Check the trajectory in between
+	var v [maxHyperDims]int
+	r := 0
+	for i, c := range d {
+		if c < 0 {
+			v[i], r = -1, -c
+		} else if c > 0 {
+			v[i], r = 1, c
+		}
+	}
+	for j := 1; j < r; j++ {
+		p := oldPos
+		for i := range v {
+			p.c[i] += j * v[i]
+		}
+		if _, ok := board.At(p); ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isDiagonalN tests if a displacement lies on a diagonal spanning exactly two axes
+func isDiagonalN(d [maxHyperDims]int) bool {
+	r, n := 0, 0
+	for _, c := range d {
+		if c == 0 {
+			continue
+		}
+		if c < 0 {
+			c = -c
+		}
+		if r != 0 && c != r {
+			return false
+		}
+		r = c
+		n++
+	}
+	return n == 2
+}
+
+// LegalMoves returns all legal moves for the player whose turn it is
+func (rs HyperChess) LegalMoves(board Board) []Move {
+	return legalMoves(rs, board, func(p Piece) []Position {
+		return rs.targets(board, p)
+	})
+}
+
+// nVectors contains the unit vectors in every direction each piece type can
+// move in, for a number of dimensions
+type nVectors struct {
+	straight, diagonal, knight, king [][maxHyperDims]int
+}
+
+// nVectorCache stores the vectors for each number of dimensions, as they
+// take a while to compute
+var nVectorCache sync.Map
+
+// vectorsN computes the unit vectors in every direction each piece type can
+// move in
+func vectorsN(dims int) *nVectors {
+	if rv, ok := nVectorCache.Load(dims); ok {
+		return rv.(*nVectors)
+	}
+
+	rv := &nVectors{}
+	var d [maxHyperDims]int
+	var rec func(i int)
+	rec = func(i int) {
+		if i < dims {
+			for d[i] = -2; d[i] <= 2; d[i]++ {
+				rec(i + 1)
+			}
+			d[i] = 0
+			return
+		}
+
+		ones, twos := 0, 0
+		for _, c := range d {
+			if c*c == 1 {
+				ones++
+			} else if c*c == 4 {
+				twos++
+			}
+		}
+		if twos == 0 && ones > 0 {
+			rv.king = append(rv.king, d)
+		}
+		if twos == 0 && ones == 1 {
+			rv.straight = append(rv.straight, d)
+		} else if twos == 0 && ones == 2 {
+			rv.diagonal = append(rv.diagonal, d)
+		} else if twos == 1 && ones == 1 {
+			rv.knight = append(rv.knight, d)
+		}
+	}
+	rec(0)
+
+	nVectorCache.Store(dims, rv)
+	return rv
+}
+
+// targets lists the positions a piece could possibly move to. The finer
+// points of the movement rules are left to CanMove.
+func (rs HyperChess) targets(board Board, piece Piece) []Position {
+	pos, ok := piece.Position.(positionN)
+	if !ok {
+		return nil
+	}
+	vectors := vectorsN(rs.Dims)
+
+	var rv []Position
+	add := func(p positionN, v [maxHyperDims]int) positionN {
+		for i := range v {
+			p.c[i] += v[i]
+		}
+		return p
+	}
+	jump := func(vectors [][maxHyperDims]int) {
+		for _, v := range vectors {
+			if p := add(pos, v); rs.onBoard(p) {
+				rv = append(rv, p)
+			}
+		}
+	}
+	slide := func(vectors [][maxHyperDims]int) {
+		for _, v := range vectors {
+			for p := add(pos, v); rs.onBoard(p); p = add(p, v) {
+				rv = append(rv, p)
+				if _, ok := board.At(p); ok {
+					break
+				}
+			}
+		}
+	}
+
+	if piece.PieceType == KING {
+		jump(vectors.king)
+	} else if piece.PieceType == QUEEN {
+		slide(vectors.straight)
+		slide(vectors.diagonal)
+	} else if piece.PieceType == BISHOP {
+		slide(vectors.diagonal)
+	} else if piece.PieceType == KNIGHT {
+		jump(vectors.knight)
+	} else if piece.PieceType == ROOK {
+		slide(vectors.straight)
+	} else if piece.PieceType == PAWN {
+		dir := 1
+		if piece.Colour == BLACK {
+			dir = -1
+		}
+		for fwd := 1; fwd < rs.Dims; fwd += 2 {
+			var v, v2 [maxHyperDims]int
+			v[fwd], v2[fwd] = dir, 2*dir
+			jump([][maxHyperDims]int{v, v2})
+			for side := 0; side < rs.Dims; side += 2 {
+				for _, s := range []int{-1, 1} {
+					c := v
+					c[side] = s
+					jump([][maxHyperDims]int{c})
+				}
+			}
+		}
+	}
+
+	return rv
+}
+
+// ApplyMove performs a move on the board, and returns the resulting board
+func (rs HyperChess) ApplyMove(board Board, move Move) (Board, error) {
+	piece, ok := board.At(move.From)
+	if !ok {
+		return Board{}, errIllegalMove
+	}
+
+	if !rs.CanMove(board, piece, move.To) {
+		return Board{}, errIllegalMove
+	}
+
+	newBoard := board.movePiece(move)
+
+	from, to := move.From.(positionN), move.To.(positionN)
+	if piece.PieceType == PAWN {
+		if board.EnPassant.canCapture(board, piece.Colour, to) {
+			newBoard = newBoard.removePiece(board.EnPassant.Pawn)
+		} else {
+			for i := 1; i < rs.Dims; i += 2 {
+				if d := to.c[i] - from.c[i]; d*d == 4 {
+					target := from
+					target.c[i] += d / 2
+					newBoard.EnPassant = EnPassant{
+						Target: target,
+						Pawn:   to,
+					}
+				}
+			}
+		}
+	}
+
+	// Pawns reaching the opponent's corner get promoted
+	opponent := BLACK
+	if piece.Colour == BLACK {
+		opponent = WHITE
+	}
+	if sum, _ := rs.forward(opponent, to); piece.PieceType == PAWN && sum == 0 {
+		if !validPromotion(move.Promotion) {
+			return Board{}, errIllegalMove
+		}
+		newBoard = newBoard.promote(move.To, move.Promotion)
+	} else if move.Promotion != 0 {
+		return Board{}, errIllegalMove
+	}
+
+	if inCheck(rs, newBoard, piece.Colour) {
+		return Board{}, errIllegalMove
+	}
+
+	newBoard = newBoard.nextTurn(rs.PlayerColours())
+
+	return newBoard, nil
+}
+
+// Status determines whether the player whose turn it is is in check, checkmate, or stalemate
+func (rs HyperChess) Status(board Board) Status {
+	return gameStatus(rs, board)
+}
@@ -0,0 +1,217 @@
+package chesseract
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHyperChessPositionParser(t *testing.T) {
+	for _, rs := range []HyperChess{NewHyperChess(2, 8), NewHyperChess(3, 5), NewHyperChess(4, 6), NewHyperChess(3, 10)} {
+		for _, p := range rs.AllPositions() {
+			q, err := rs.ParsePosition(p.String())
+			if err != nil {
+				t.Errorf("%s: error parsing position '%s': %v", rs, p, err)
+			} else if !p.Equals(q) {
+				t.Errorf("%s: position '%s' turns into '%s'", rs, p, q)
+			}
+		}
+	}
+
+	rs := NewHyperChess(3, 5)
+	invalidValues := []string{
+		"",
+		"a1",
+		"a1a1",
+		"a0a",
+		"a01a",
+		"f1a",
+		"a6a",
+		"a1f",
+		"1aa",
+		"aa1",
+		"a1a ",
+	}
+	for _, s := range invalidValues {
+		p, err := rs.ParsePosition(s)
+		if err == nil {
+			t.Errorf("String '%s' decodes into '%s' - not good", s, p)
+		}
+	}
+
+	if p, err := NewHyperChess(3, 10).ParsePosition("j10c"); err != nil || p.String() != "j10c" {
+		t.Errorf("Position j10c parses as %v, %v", p, err)
+	}
+}
+
+func TestHyperChessRegistration(t *testing.T) {
+	for _, name := range []string{"Hyper2x8", "Hyper3x5", "Hyper4x6", "Hyper5x4"} {
+		rs := GetRuleSet(name)
+		if rs == nil {
+			t.Errorf("Rule set '%s' isn't registered", name)
+		} else if rs.String() != name {
+			t.Errorf("Rule set '%s' is called '%s'", name, rs)
+		}
+	}
+}
+
+func TestHyperChessDefaultBoard(t *testing.T) {
+	for _, rs := range []HyperChess{NewHyperChess(2, 8), NewHyperChess(3, 5), NewHyperChess(4, 6)} {
+		board := rs.DefaultBoard()
+
+		count := make(map[PieceType]int)
+		colours := make(map[Colour]int)
+		for _, p := range rs.AllPositions() {
+			if pc, ok := board.At(p); ok {
+				count[pc.PieceType]++
+				colours[pc.Colour]++
+			}
+		}
+
+		if colours[WHITE] != colours[BLACK] || count[KING] != 2 || count[QUEEN] != 2 || len(board.Pieces) != colours[WHITE]+colours[BLACK] {
+			t.Errorf("%s: unexpected default board: %v %v", rs, count, colours)
+		}
+
+		if st := rs.Status(board); st != NORMAL {
+			t.Errorf("%s: unexpected status '%s' for the default board", rs, st)
+		}
+	}
+
+	// The two-dimensional version should look familiar
+	rs := NewHyperChess(2, 8)
+	board := rs.DefaultBoard()
+	for x, pt := range []PieceType{ROOK, KNIGHT, BISHOP, QUEEN, KING, BISHOP, KNIGHT, ROOK} {
+		p, _ := rs.ParsePosition(string('a'+rune(x)) + "8")
+		if pc, ok := board.At(p); !ok || pc.PieceType != pt || pc.Colour != BLACK {
+			t.Errorf("Unexpected piece %v at %s", pc, p)
+		}
+	}
+}
+
+func TestHyperChessPerft(t *testing.T) {
+	// Without castling, the first few plies are the same as regular chess
+	rs := NewHyperChess(2, 8)
+	for depth, expected := range []int{1, 20, 400, 8902} {
+		if n := Perft(rs, rs.DefaultBoard(), depth); n != expected {
+			t.Errorf("perft(%d) = %d; expected %d", depth, n, expected)
+		}
+	}
+}
+
+func TestHyperChessMovementRules(t *testing.T) {
+	// Piece movement in four dimensions should match Chesseract's
+	rs := NewHyperChess(4, 6)
+	pos := func(s string) Position {
+		p, err := rs.ParsePosition(s)
+		if err != nil {
+			t.Fatalf("error parsing '%s': %v", s, err)
+		}
+		return p
+	}
+
+	type testCase struct {
+		PieceIndex               int
+		ExpectedReachableSquares int
+	}
+	type testSuite struct {
+		Board Board
+		Cases []testCase
+	}
+
+	suite := []testSuite{
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{ROOK, WHITE, pos("c3c3")},
+					{BISHOP, WHITE, pos("a1a1")},
+					{KNIGHT, WHITE, pos("f6f6")},
+				},
+			},
+			Cases: []testCase{
+				{0, 20},
+				{1, 30},
+				{2, 12},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{KING, BLACK, pos("c3c3")},
+					{QUEEN, WHITE, pos("d4d4")},
+				},
+			},
+			Cases: []testCase{
+				{0, 80},
+				{1, 74},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{PAWN, WHITE, pos("c2c1")},
+					{PAWN, BLACK, pos("c5c6")},
+					{PAWN, BLACK, pos("b3c1")},
+				},
+			},
+			Cases: []testCase{
+				{0, 5},
+				{1, 4},
+				{2, 2},
+			},
+		},
+	}
+
+	for _, ts := range suite {
+		for _, tc := range ts.Cases {
+			hl := []Position{}
+			piece := ts.Board.Pieces[tc.PieceIndex]
+			for _, p := range rs.AllPositions() {
+				if rs.CanMove(ts.Board, piece, p) {
+					hl = append(hl, p)
+				}
+			}
+			if len(hl) != tc.ExpectedReachableSquares {
+				t.Errorf("Expected piece at %s to be able to move to %d squares, but measured %d", piece.Position, tc.ExpectedReachableSquares, len(hl))
+				for _, p := range hl {
+					t.Logf("    %s", p)
+				}
+			}
+		}
+	}
+}
+
+func TestHyperChessMatch(t *testing.T) {
+	rs := NewHyperChess(3, 5)
+	match := Match{
+		RuleSet: rs,
+		Board:   rs.DefaultBoard(),
+	}
+
+	for i := 0; i < 8; i++ {
+		moves := LegalMoves(rs, match.Board)
+		if len(moves) == 0 {
+			t.Fatalf("no legal moves after %d plies", i)
+		}
+		s := SAN(rs, match.Board, moves[(7*i)%len(moves)])
+		move, err := ParseSAN(rs, match.Board, s)
+		if err != nil {
+			t.Fatalf("error parsing '%s': %v", s, err)
+		}
+		if san := SAN(rs, match.Board, move); san != s {
+			t.Errorf("Move '%s' is written as '%s'", s, san)
+		}
+		newBoard, err := rs.ApplyMove(match.Board, move)
+		if err != nil {
+			t.Fatalf("applying move '%s': %v", s, err)
+		}
+		match.Moves = append(match.Moves, move)
+		match.Board = newBoard
+	}
+
+	var buf bytes.Buffer
+	match.DebugDump(&buf, nil)
+	t.Logf("%s", buf.String())
+
+	if len(LegalMoves(rs, match.Board)) == 0 {
+		t.Errorf("White has no moves")
+	}
+}
@@ -0,0 +1,199 @@
+package chesseract
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Hyper-FEN is an extension of Forsyth-Edwards Notation for the 6×6×6×6
+// Chesseract board. The piece placement consists of 36 planes, each of which
+// is encoded like the piece placement in regular FEN: ranks are listed from 6
+// down to 1, separated by a '/'. Completely empty planes can be abbreviated
+// as '-'.
+// The planes are grouped by their w coordinate; within each group planes are
+// listed from r down to m, separated by ':'. The groups are listed from 6
+// down to 1, separated by '|'.
+// The placement is followed by the side to move, the castling rights (a
+// comma-separated list of rook positions), the en passant target and pawn
+// (separated by a comma), the half-move clock, and the move number.
+
+// ParseHyperFEN decodes a position in Hyper-FEN into a board for the Chesseract rule set
+func ParseHyperFEN(s string) (Board, error) {
+	rs := Chesseract{}
+	fields := strings.Fields(s)
+	if len(fields) < 4 {
+		return Board{}, fmt.Errorf("invalid Hyper-FEN '%s': expected at least 4 fields", s)
+	}
+
+	rv := Board{}
+
+	groups := strings.Split(fields[0], "|")
+	if len(groups) != 6 {
+		return Board{}, fmt.Errorf("invalid Hyper-FEN '%s': expected 6 groups of planes", s)
+	}
+	for i, group := range groups {
+		w := 5 - i
+		planes := strings.Split(group, ":")
+		if len(planes) != 6 {
+			return Board{}, fmt.Errorf("invalid Hyper-FEN '%s': expected 6 planes in group %d", s, w+1)
+		}
+		for j, plane := range planes {
+			z := 5 - j
+			if plane == "-" {
+				continue
+			}
+			ranks := strings.Split(plane, "/")
+			if len(ranks) != 6 {
+				return Board{}, fmt.Errorf("invalid Hyper-FEN '%s': expected 6 ranks in plane '%s'", s, plane)
+			}
+			for k, rank := range ranks {
+				y := 5 - k
+				x := 0
+				for _, c := range rank {
+					if c >= '1' && c <= '6' {
+						x += int(c - '0')
+						continue
+					}
+					pt, err := ParsePieceType(string(c))
+					if err != nil || x >= 6 {
+						return Board{}, fmt.Errorf("invalid Hyper-FEN '%s': bad rank '%s'", s, rank)
+					}
+					colour := WHITE
+					if c >= 'a' && c <= 'z' {
+						colour = BLACK
+					}
+					rv.Pieces = append(rv.Pieces, Piece{pt, colour, position4D{x, y, z, w}})
+					x++
+				}
+				if x != 6 {
+					return Board{}, fmt.Errorf("invalid Hyper-FEN '%s': bad rank '%s'", s, rank)
+				}
+			}
+		}
+	}
+
+	if fields[1] == "w" {
+		rv.Turn = WHITE
+	} else if fields[1] == "b" {
+		rv.Turn = BLACK
+	} else {
+		return Board{}, fmt.Errorf("invalid Hyper-FEN '%s': unknown side to move '%s'", s, fields[1])
+	}
+
+	if fields[2] != "-" {
+		for _, r := range strings.Split(fields[2], ",") {
+			pos, err := rs.ParsePosition(r)
+			if err != nil {
+				return Board{}, fmt.Errorf("invalid Hyper-FEN '%s': bad castling rook '%s'", s, r)
+			}
+			rv.Castling = append(rv.Castling, pos)
+		}
+	}
+
+	if fields[3] != "-" {
+		ep := strings.Split(fields[3], ",")
+		if len(ep) != 2 {
+			return Board{}, fmt.Errorf("invalid Hyper-FEN '%s': bad en passant field '%s'", s, fields[3])
+		}
+		var err error
+		rv.EnPassant.Target, err = rs.ParsePosition(ep[0])
+		if err == nil {
+			rv.EnPassant.Pawn, err = rs.ParsePosition(ep[1])
+		}
+		if err != nil {
+			return Board{}, fmt.Errorf("invalid Hyper-FEN '%s': bad en passant field '%s'", s, fields[3])
+		}
+	}
+
+	if len(fields) > 4 {
+		if _, err := fmt.Sscanf(fields[4], "%d", &rv.HalfMoveClock); err != nil {
+			return Board{}, fmt.Errorf("invalid Hyper-FEN '%s': bad half-move clock '%s'", s, fields[4])
+		}
+	}
+	if len(fields) > 5 {
+		var n int
+		if _, err := fmt.Sscanf(fields[5], "%d", &n); err != nil || n < 1 {
+			return Board{}, fmt.Errorf("invalid Hyper-FEN '%s': bad move number '%s'", s, fields[5])
+		}
+		rv.FullMoves = n - 1
+	}
+
+	return rv, nil
+}
+
+// HyperFEN encodes a Chesseract board in Hyper-FEN
+func (b Board) HyperFEN() string {
+	var sb strings.Builder
+
+	for w := 5; w >= 0; w-- {
+		for z := 5; z >= 0; z-- {
+			var plane strings.Builder
+			occupied := false
+			for y := 5; y >= 0; y-- {
+				empty := 0
+				for x := 0; x < 6; x++ {
+					pc, ok := b.At(position4D{x, y, z, w})
+					if !ok {
+						empty++
+						continue
+					}
+					occupied = true
+					if empty > 0 {
+						fmt.Fprintf(&plane, "%d", empty)
+						empty = 0
+					}
+					if pc.Colour == BLACK {
+						plane.WriteString(strings.ToLower(pc.PieceType.Letter()))
+					} else {
+						plane.WriteString(pc.PieceType.Letter())
+					}
+				}
+				if empty > 0 {
+					fmt.Fprintf(&plane, "%d", empty)
+				}
+				if y > 0 {
+					plane.WriteByte('/')
+				}
+			}
+
+			if occupied {
+				sb.WriteString(plane.String())
+			} else {
+				sb.WriteByte('-')
+			}
+			if z > 0 {
+				sb.WriteByte(':')
+			}
+		}
+		if w > 0 {
+			sb.WriteByte('|')
+		}
+	}
+
+	if b.Turn == BLACK {
+		sb.WriteString(" b ")
+	} else {
+		sb.WriteString(" w ")
+	}
+
+	if len(b.Castling) == 0 {
+		sb.WriteString("-")
+	} else {
+		for i, r := range b.Castling {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(r.String())
+		}
+	}
+
+	if b.EnPassant.Target != nil && b.EnPassant.Pawn != nil {
+		fmt.Fprintf(&sb, " %s,%s", b.EnPassant.Target, b.EnPassant.Pawn)
+	} else {
+		sb.WriteString(" -")
+	}
+
+	fmt.Fprintf(&sb, " %d %d", b.HalfMoveClock, b.FullMoves+1)
+
+	return sb.String()
+}
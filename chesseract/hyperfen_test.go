@@ -0,0 +1,77 @@
+package chesseract
+
+import (
+	"testing"
+)
+
+func TestHyperFEN(t *testing.T) {
+	rs := Chesseract{}
+	board := rs.DefaultBoard()
+
+	s := board.HyperFEN()
+	decoded, err := ParseHyperFEN(s)
+	if err != nil {
+		t.Fatalf("error parsing default board: %v", err)
+	}
+	if decoded.Hash() != board.Hash() {
+		t.Errorf("Default board changed after encoding as '%s'", s)
+	}
+
+	// Advance a pawn two squares, so there's an en passant target
+	board, err = rs.ApplyMove(board, Move{PAWN, position4D{2, 2, 2, 0}, position4D{2, 4, 2, 0}, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	board.Castling = []Position{position4D{2, 0, 0, 0}}
+	s = board.HyperFEN()
+	decoded, err = ParseHyperFEN(s)
+	if err != nil {
+		t.Fatalf("error parsing '%s': %v", s, err)
+	}
+	if decoded.Hash() != board.Hash() || decoded.HyperFEN() != s {
+		t.Errorf("Board changed after encoding as '%s'", s)
+	}
+	if !decoded.EnPassant.Pawn.Equals(position4D{2, 4, 2, 0}) {
+		t.Errorf("En passant pawn is at %s", decoded.EnPassant.Pawn)
+	}
+	if decoded.FullMoves != 0 || decoded.Turn != BLACK {
+		t.Errorf("Move counters or turn are wrong in '%s'", s)
+	}
+
+	// A single line fixture: two kings and a white rook
+	fixture := "-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:6/6/6/6/6/k5:K4R/6/6/6/6/6 b - - 3 20"
+	board, err = ParseHyperFEN(fixture)
+	if err != nil {
+		t.Fatalf("error parsing fixture: %v", err)
+	}
+	for _, expected := range []Piece{
+		{KING, BLACK, position4D{0, 0, 1, 0}},
+		{KING, WHITE, position4D{0, 5, 0, 0}},
+		{ROOK, WHITE, position4D{5, 5, 0, 0}},
+	} {
+		if pc, ok := board.At(expected.Position); !ok || pc != expected {
+			t.Errorf("Expected %s %s at %s, found %v", expected.Colour, expected.PieceType, expected.Position, pc)
+		}
+	}
+	if len(board.Pieces) != 3 || board.HalfMoveClock != 3 || board.FullMoves != 19 {
+		t.Errorf("Fixture decoded incorrectly")
+	}
+	if s := board.HyperFEN(); s != fixture {
+		t.Errorf("Fixture turns into '%s'", s)
+	}
+
+	invalid := []string{
+		"",
+		"-:-:-:-:-:- w - - 0 1",
+		"-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:7/6/6/6/6/6 w - - 0 1",
+		"-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:6/6/6/6/6 w - - 0 1",
+		"-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:- x - - 0 1",
+		"-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:- w g1m1 - 0 1",
+		"-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:-|-:-:-:-:-:- w - a3m1 0 1",
+	}
+	for _, s := range invalid {
+		if _, err := ParseHyperFEN(s); err == nil {
+			t.Errorf("Hyper-FEN '%s' should not parse", s)
+		}
+	}
+}
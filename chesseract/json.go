@@ -9,10 +9,11 @@ import (
 
 // The matchJsonProxy struct is a JSON proxy for the Match struct
 type matchJsonProxy struct {
-	RuleSet   string          `json:"ruleset"`
-	Board     boardJsonProxy  `json:"board"`
-	StartTime string          `json:"start_time,omitempty"`
-	Moves     []moveJsonProxy `json:"moves"`
+	RuleSet       string          `json:"ruleset"`
+	Board         boardJsonProxy  `json:"board"`
+	StartingBoard *boardJsonProxy `json:"starting_board,omitempty"`
+	StartTime     string          `json:"start_time,omitempty"`
+	Moves         []moveJsonProxy `json:"moves"`
 }
 
 // The positionJsonProxy is a JSON proxy for the Position interface
@@ -27,54 +28,140 @@ type pieceJsonProxy struct {
 
 // The boardJsonProxy struct is a JSON proxy for the Board struct
 type boardJsonProxy struct {
-	Pieces []pieceJsonProxy `json:"pieces"`
-	Turn   Colour           `json:"turn"`
+	Pieces        []pieceJsonProxy    `json:"pieces"`
+	Turn          Colour              `json:"turn"`
+	Castling      []positionJsonProxy `json:"castling,omitempty"`
+	EnPassant     *enPassantJsonProxy `json:"en_passant,omitempty"`
+	HalfMoveClock int                 `json:"halfmove_clock,omitempty"`
+	FullMoves     int                 `json:"full_moves,omitempty"`
+	Reserve       []reserveJsonProxy  `json:"reserve,omitempty"`
+	Partial       bool                `json:"partial,omitempty"`
 }
 
-// The moveJsonProxy struct is a JSON proxy for the Move struct
+// The reserveJsonProxy struct is a JSON proxy for a Piece in reserve, which
+// has no position
+type reserveJsonProxy struct {
+	PieceType PieceType `json:"type"`
+	Colour    Colour    `json:"colour"`
+}
+
+// The enPassantJsonProxy struct is a JSON proxy for the EnPassant struct
+type enPassantJsonProxy struct {
+	Target positionJsonProxy `json:"target"`
+	Pawn   positionJsonProxy `json:"pawn"`
+}
+
+// The moveJsonProxy struct is a JSON proxy for the Move struct. Drops have no
+// origin, and hidden moves have neither an origin nor a destination.
 type moveJsonProxy struct {
 	PieceType PieceType         `json:"type"`
-	From      positionJsonProxy `json:"from"`
-	To        positionJsonProxy `json:"to"`
+	From      positionJsonProxy `json:"from,omitempty"`
+	To        positionJsonProxy `json:"to,omitempty"`
+	Promotion PieceType         `json:"promotion,omitempty"`
 	Time      string            `json:"time,omitempty"`
 }
 
 func (m Move) MarshalJSON() ([]byte, error) {
-	proxy := moveJsonProxy{
+	return json.Marshal(moveProxy(m))
+}
+
+// moveProxy converts a Move into its JSON proxy
+func moveProxy(m Move) moveJsonProxy {
+	rv := moveJsonProxy{
 		PieceType: m.PieceType,
-		From:      positionJsonProxy(m.From.String()),
-		To:        positionJsonProxy(m.To.String()),
+		Promotion: m.Promotion,
 		Time:      m.Time.String(),
 	}
-	return json.Marshal(proxy)
+	if m.From != nil {
+		rv.From = positionJsonProxy(m.From.String())
+	}
+	if m.To != nil {
+		rv.To = positionJsonProxy(m.To.String())
+	}
+	return rv
 }
 
-func (m Match) MarshalJSON() ([]byte, error) {
-	proxy := matchJsonProxy{
-		RuleSet: m.RuleSet.String(),
-		Board: boardJsonProxy{
-			Turn: m.Board.Turn,
-		},
-		StartTime: m.StartTime.Format(time.RFC3339),
+// parseMoveProxy converts a JSON proxy back into a Move
+func parseMoveProxy(rs RuleSet, proxy moveJsonProxy) (Move, error) {
+	rv := Move{
+		PieceType: proxy.PieceType,
+		Promotion: proxy.Promotion,
 	}
 
-	for _, pc := range m.Board.Pieces {
-		proxy.Board.Pieces = append(proxy.Board.Pieces, pieceJsonProxy{
+	var err error
+	if proxy.From != "" {
+		rv.From, err = rs.ParsePosition(string(proxy.From))
+		if err != nil {
+			return Move{}, err
+		}
+	}
+	if proxy.To != "" {
+		rv.To, err = rs.ParsePosition(string(proxy.To))
+		if err != nil {
+			return Move{}, err
+		}
+	}
+	rv.Time, err = time.ParseDuration(proxy.Time)
+	if err != nil {
+		return Move{}, err
+	}
+	return rv, nil
+}
+
+// boardProxy converts a Board into its JSON proxy
+func boardProxy(b Board) boardJsonProxy {
+	rv := boardJsonProxy{
+		Turn:          b.Turn,
+		HalfMoveClock: b.HalfMoveClock,
+		FullMoves:     b.FullMoves,
+		Partial:       b.Partial,
+	}
+
+	for _, pc := range b.Pieces {
+		rv.Pieces = append(rv.Pieces, pieceJsonProxy{
 			PieceType: pc.PieceType,
 			Colour:    pc.Colour,
 			Position:  positionJsonProxy(pc.Position.String()),
 		})
 	}
 
-	for _, mv := range m.Moves {
-		proxy.Moves = append(proxy.Moves, moveJsonProxy{
-			PieceType: mv.PieceType,
-			From:      positionJsonProxy(mv.From.String()),
-			To:        positionJsonProxy(mv.To.String()),
-			Time:      mv.Time.String(),
+	for _, pos := range b.Castling {
+		rv.Castling = append(rv.Castling, positionJsonProxy(pos.String()))
+	}
+
+	if b.EnPassant.Target != nil && b.EnPassant.Pawn != nil {
+		rv.EnPassant = &enPassantJsonProxy{
+			Target: positionJsonProxy(b.EnPassant.Target.String()),
+			Pawn:   positionJsonProxy(b.EnPassant.Pawn.String()),
+		}
+	}
+
+	for _, pc := range b.Reserve {
+		rv.Reserve = append(rv.Reserve, reserveJsonProxy{
+			PieceType: pc.PieceType,
+			Colour:    pc.Colour,
 		})
 	}
 
+	return rv
+}
+
+func (m Match) MarshalJSON() ([]byte, error) {
+	proxy := matchJsonProxy{
+		RuleSet:   m.RuleSet.String(),
+		Board:     boardProxy(m.Board),
+		StartTime: m.StartTime.Format(time.RFC3339),
+	}
+
+	if m.StartingBoard != nil {
+		sb := boardProxy(*m.StartingBoard)
+		proxy.StartingBoard = &sb
+	}
+
+	for _, mv := range m.Moves {
+		proxy.Moves = append(proxy.Moves, moveProxy(mv))
+	}
+
 	return json.Marshal(proxy)
 }
 
@@ -95,43 +182,79 @@ func (m *Match) UnmarshalJSON(buf []byte) error {
 		return errors.Wrap(err, "error decoding match")
 	}
 
-	m.Board = Board{
-		Pieces: nil,
-		Turn:   proxy.Board.Turn,
+	m.Board, err = parseBoardProxy(m.RuleSet, proxy.Board)
+	if err != nil {
+		return errors.Wrap(err, "error decoding match")
 	}
-	for _, pc := range proxy.Board.Pieces {
-		pos, err := m.RuleSet.ParsePosition(string(pc.Position))
+
+	m.StartingBoard = nil
+	if proxy.StartingBoard != nil {
+		sb, err := parseBoardProxy(m.RuleSet, *proxy.StartingBoard)
 		if err != nil {
 			return errors.Wrap(err, "error decoding match")
 		}
-		m.Board.Pieces = append(m.Board.Pieces, Piece{
+		m.StartingBoard = &sb
+	}
+
+	m.Moves = nil
+	for _, mv := range proxy.Moves {
+		move, err := parseMoveProxy(m.RuleSet, mv)
+		if err != nil {
+			return errors.Wrap(err, "error decoding match")
+		}
+		m.Moves = append(m.Moves, move)
+	}
+
+	return nil
+}
+
+// parseBoardProxy converts a JSON proxy back into a Board
+func parseBoardProxy(rs RuleSet, proxy boardJsonProxy) (Board, error) {
+	rv := Board{
+		Pieces:        nil,
+		Turn:          proxy.Turn,
+		HalfMoveClock: proxy.HalfMoveClock,
+		FullMoves:     proxy.FullMoves,
+		Partial:       proxy.Partial,
+	}
+	for _, pc := range proxy.Pieces {
+		pos, err := rs.ParsePosition(string(pc.Position))
+		if err != nil {
+			return Board{}, err
+		}
+		rv.Pieces = append(rv.Pieces, Piece{
 			PieceType: pc.PieceType,
 			Colour:    pc.Colour,
 			Position:  pos,
 		})
 	}
 
-	m.Moves = nil
-	for _, mv := range proxy.Moves {
-		from, err := m.RuleSet.ParsePosition(string(mv.From))
+	for _, pc := range proxy.Castling {
+		pos, err := rs.ParsePosition(string(pc))
 		if err != nil {
-			return errors.Wrap(err, "error decoding match")
+			return Board{}, err
 		}
-		to, err := m.RuleSet.ParsePosition(string(mv.To))
+		rv.Castling = append(rv.Castling, pos)
+	}
+
+	if ep := proxy.EnPassant; ep != nil {
+		var err error
+		rv.EnPassant.Target, err = rs.ParsePosition(string(ep.Target))
 		if err != nil {
-			return errors.Wrap(err, "error decoding match")
+			return Board{}, err
 		}
-		dur, err := time.ParseDuration(mv.Time)
+		rv.EnPassant.Pawn, err = rs.ParsePosition(string(ep.Pawn))
 		if err != nil {
-			return errors.Wrap(err, "error decoding match")
+			return Board{}, err
 		}
-		m.Moves = append(m.Moves, Move{
-			PieceType: mv.PieceType,
-			From:      from,
-			To:        to,
-			Time:      dur,
+	}
+
+	for _, pc := range proxy.Reserve {
+		rv.Reserve = append(rv.Reserve, Piece{
+			PieceType: pc.PieceType,
+			Colour:    pc.Colour,
 		})
 	}
 
-	return nil
+	return rv, nil
 }
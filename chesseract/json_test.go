@@ -41,7 +41,7 @@ func TestMarshalMatch(t *testing.T) {
 		to, _ := rs.ParsePosition(m.To)
 
 		dur := int64(i*5000) + 3000
-		move := Move{piece.PieceType, from, to, time.Duration(dur) * time.Millisecond}
+		move := Move{piece.PieceType, from, to, 0, time.Duration(dur) * time.Millisecond}
 		newBoard, _ := rs.ApplyMove(match.Board, move)
 
 		match.Moves = append(match.Moves, move)
@@ -61,7 +61,7 @@ func TestMarshalMatch(t *testing.T) {
 	}
 
 	h := fmt.Sprintf("%x", sha.Sum(nil))
-	exp := "333711c1a488e8668bf8f6ac186ac2ab83c912a28ec2f4d7b3b5d73f6e4b090e"
+	exp := "a8bd250007c6efe9d5d50ebec932bb13bd261ed006da2c63c949e46c6fb8699e"
 
 	fmt.Printf("Observed hash: %s\n", h)
 	fmt.Printf("Expected hash: %s\n", exp)
@@ -99,6 +99,20 @@ func TestMarshalMatch(t *testing.T) {
 		}
 	}
 
+	if len(match.Board.Castling) != len(decodedMatch.Board.Castling) {
+		t.Errorf("castling rights length mismatch")
+	} else {
+		for i, pos := range match.Board.Castling {
+			if !pos.Equals(decodedMatch.Board.Castling[i]) {
+				t.Errorf("Castling right %d is somehow different now", i+1)
+			}
+		}
+	}
+
+	if match.Board.HalfMoveClock != decodedMatch.Board.HalfMoveClock || match.Board.FullMoves != decodedMatch.Board.FullMoves {
+		t.Errorf("Move counters are somehow different now")
+	}
+
 	for i, amv := range match.Moves {
 		bmv := decodedMatch.Moves[i]
 
@@ -133,3 +147,30 @@ func TestThatImNotCrazy(t *testing.T) {
 		t.Errorf("s.A: %d", s.A)
 	}
 }
+
+func TestMarshalEnPassant(t *testing.T) {
+	rs := Boring2D{}
+	match := Match{
+		RuleSet: rs,
+		Board:   rs.DefaultBoard(),
+	}
+	move := Move{PAWN, position2D{4, 1}, position2D{4, 3}, 0, 0}
+	match.Board, _ = rs.ApplyMove(match.Board, move)
+	match.Moves = append(match.Moves, move)
+
+	buf, err := json.Marshal(match)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decodedMatch Match
+	err = json.Unmarshal(buf, &decodedMatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ep := decodedMatch.Board.EnPassant
+	if ep.Target == nil || !ep.Target.Equals(position2D{4, 2}) || ep.Pawn == nil || !ep.Pawn.Equals(position2D{4, 3}) {
+		t.Errorf("En passant target not preserved: %s", buf)
+	}
+}
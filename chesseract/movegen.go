@@ -0,0 +1,82 @@
+package chesseract
+
+// A MoveGenerator is a RuleSet that can efficiently list all legal moves in a position
+type MoveGenerator interface {
+	RuleSet
+
+	// LegalMoves returns all legal moves for the player whose turn it is
+	LegalMoves(Board) []Move
+}
+
+// LegalMoves returns all legal moves for the player whose turn it is. If the
+// rule set does not implement MoveGenerator, this falls back to trying every
+// position on the board for every piece.
+func LegalMoves(rs RuleSet, board Board) []Move {
+	if mg, ok := rs.(MoveGenerator); ok {
+		return mg.LegalMoves(board)
+	}
+
+	all := rs.AllPositions()
+	return legalMoves(rs, board, func(Piece) []Position {
+		return all
+	})
+}
+
+// promotionTypes lists the piece types a pawn can be promoted to in any of
+// the rule sets
+var promotionTypes = []PieceType{QUEEN, ROOK, BISHOP, KNIGHT, UNICORN}
+
+// legalMoves filters a list of candidate target positions for each piece down
+// to the moves that are actually legal
+func legalMoves(rs RuleSet, board Board, targets func(Piece) []Position) []Move {
+	var rv []Move
+	findLegalMoves(rs, board, targets, func(move Move) bool {
+		rv = append(rv, move)
+		return true
+	})
+	return rv
+}
+
+// hasLegalMove tests if the player whose turn it is can make any move at all.
+// This stops looking as soon as it finds one.
+func hasLegalMove(rs RuleSet, board Board, targets func(Piece) []Position) bool {
+	rv := false
+	findLegalMoves(rs, board, targets, func(Move) bool {
+		rv = true
+		return false
+	})
+	return rv
+}
+
+// findLegalMoves calls f for each legal move, until it returns false
+func findLegalMoves(rs RuleSet, board Board, targets func(Piece) []Position, f func(Move) bool) {
+	board = board.indexed()
+
+	for _, p := range board.Pieces {
+		if p.Colour != board.Turn {
+			continue
+		}
+		for _, pos := range targets(p) {
+			if !rs.CanMove(board, p, pos) {
+				continue
+			}
+
+			move := Move{PieceType: p.PieceType, From: p.Position, To: pos}
+			if _, err := rs.ApplyMove(board, move); err == nil {
+				if !f(move) {
+					return
+				}
+			} else if p.PieceType == PAWN {
+				// Maybe it's only illegal because it lacks a promotion
+				for _, pt := range promotionTypes {
+					move.Promotion = pt
+					if _, err := rs.ApplyMove(board, move); err == nil {
+						if !f(move) {
+							return
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package chesseract
+
+import (
+	"testing"
+)
+
+// bruteForceMoves lists legal moves by trying every position on the board
+func bruteForceMoves(rs RuleSet, board Board) []Move {
+	all := rs.AllPositions()
+	return legalMoves(rs, board, func(Piece) []Position {
+		return all
+	})
+}
+
+func compareMoves(t *testing.T, rs MoveGenerator, board Board) int {
+	expected := bruteForceMoves(rs, board)
+	measured := rs.LegalMoves(board)
+
+	seen := make(map[string]bool)
+	for _, m := range measured {
+		seen[m.String()] = true
+	}
+	for _, m := range expected {
+		if !seen[m.String()] {
+			t.Errorf("Move %s is missing from the generated moves", m)
+		}
+	}
+	if len(measured) != len(expected) {
+		t.Errorf("Generated %d moves, but expected %d", len(measured), len(expected))
+	}
+	return len(measured)
+}
+
+func TestBoring2DLegalMoves(t *testing.T) {
+	rs := Boring2D{}
+	board := rs.DefaultBoard()
+
+	if n := compareMoves(t, rs, board); n != 20 {
+		t.Errorf("Expected 20 legal moves from the starting position, got %d", n)
+	}
+
+	// Castling, en passant and promotion
+	board = Board{
+		Pieces: []Piece{
+			{KING, WHITE, position2D{4, 0}},
+			{ROOK, WHITE, position2D{7, 0}},
+			{PAWN, WHITE, position2D{4, 4}},
+			{PAWN, WHITE, position2D{1, 6}},
+			{KING, BLACK, position2D{4, 7}},
+			{PAWN, BLACK, position2D{3, 4}},
+		},
+		Castling:  []Position{position2D{7, 0}},
+		EnPassant: EnPassant{Target: position2D{3, 5}, Pawn: position2D{3, 4}},
+		Turn:      WHITE,
+	}
+	compareMoves(t, rs, board)
+}
+
+func TestChesseractLegalMoves(t *testing.T) {
+	rs := Chesseract{}
+	board := rs.DefaultBoard()
+	n := compareMoves(t, rs, board)
+	t.Logf("%d legal moves from the starting position", n)
+
+	board.Turn = BLACK
+	compareMoves(t, rs, board)
+}
@@ -0,0 +1,309 @@
+package chesseract
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LongAlgebraic renders a move in long algebraic notation, e.g. 'Ng1-f3' or
+// 'e7xd8=Q+'. Drops are written as 'N@f3'. The board is the board before the
+// move was made.
+func LongAlgebraic(rs RuleSet, board Board, move Move) string {
+	if move.IsHidden() {
+		return "?"
+	} else if move.IsDrop() {
+		return dropNotation(move) + checkSuffix(rs, board, move)
+	}
+
+	var sb strings.Builder
+
+	piece, _ := board.At(move.From)
+	if piece.PieceType != PAWN && piece.PieceType != 0 {
+		sb.WriteString(piece.PieceType.Letter())
+	}
+	sb.WriteString(move.From.String())
+	if isCapture(board, piece, move) {
+		sb.WriteByte('x')
+	} else {
+		sb.WriteByte('-')
+	}
+	sb.WriteString(move.To.String())
+	if move.Promotion != 0 {
+		sb.WriteString("=" + move.Promotion.Letter())
+	}
+	sb.WriteString(checkSuffix(rs, board, move))
+
+	return sb.String()
+}
+
+// ParseLongAlgebraic decodes a move in long algebraic notation. The separator
+// between the two positions and the piece letter are optional, so that
+// 'e2e4' and 'Pe2-e4' also work.
+func ParseLongAlgebraic(rs RuleSet, board Board, s string) (Move, error) {
+	orig := s
+	s = strings.TrimRight(s, "+#!?")
+
+	var rv Move
+	if i := strings.Index(s, "@"); i >= 0 {
+		return parseDrop(rs, s[:i], s[i+1:], orig)
+	}
+
+	if i := strings.Index(s, "="); i >= 0 {
+		pt, err := ParsePieceType(s[i+1:])
+		if err != nil {
+			return Move{}, fmt.Errorf("invalid promotion in move '%s'", orig)
+		}
+		rv.Promotion = pt
+		s = s[:i]
+	}
+
+	// The piece letter is optional, as it's implied by the starting
+	// position. Positions may start with a capital letter too, though, so
+	// only skip it if that's the only way to make sense of the move.
+	var ok bool
+	rv.From, rv.To, ok = splitLongAlgebraic(rs, s)
+	if !ok && len(s) > 0 && s[0] >= 'A' && s[0] <= 'Z' {
+		rv.From, rv.To, ok = splitLongAlgebraic(rs, s[1:])
+	}
+	if !ok {
+		return Move{}, fmt.Errorf("invalid move '%s'", orig)
+	}
+
+	piece, ok := board.At(rv.From)
+	if !ok {
+		return Move{}, fmt.Errorf("there is no piece at %s", rv.From)
+	}
+	rv.PieceType = piece.PieceType
+
+	return rv, nil
+}
+
+// parseDrop decodes a drop such as 'N@f3'. Pawn drops may omit the piece letter.
+func parseDrop(rs RuleSet, piece, to, orig string) (Move, error) {
+	rv := Move{PieceType: PAWN}
+	if piece != "" {
+		pt, err := ParsePieceType(piece)
+		if err != nil {
+			return Move{}, fmt.Errorf("invalid piece in move '%s'", orig)
+		}
+		rv.PieceType = pt
+	}
+
+	var err error
+	rv.To, err = rs.ParsePosition(to)
+	if err != nil {
+		return Move{}, fmt.Errorf("invalid move '%s'", orig)
+	}
+	return rv, nil
+}
+
+// dropNotation renders a drop, e.g. 'N@f3' or 'P@e4'
+func dropNotation(move Move) string {
+	return move.PieceType.Letter() + "@" + move.To.String()
+}
+
+// splitLongAlgebraic finds the point where the origin ends and the
+// destination starts: the separator if there is one, or otherwise the first
+// point where both halves are valid positions
+func splitLongAlgebraic(rs RuleSet, s string) (from, to Position, ok bool) {
+	if i := strings.IndexAny(s, "-x"); i >= 0 {
+		from, err := rs.ParsePosition(s[:i])
+		if err != nil {
+			return nil, nil, false
+		}
+		to, err := rs.ParsePosition(s[i+1:])
+		if err != nil {
+			return nil, nil, false
+		}
+		return from, to, true
+	}
+
+	for i := 1; i < len(s); i++ {
+		from, err := rs.ParsePosition(s[:i])
+		if err != nil {
+			continue
+		}
+		if to, err := rs.ParsePosition(s[i:]); err == nil {
+			return from, to, true
+		}
+	}
+	return nil, nil, false
+}
+
+// isCapture tests if a move captures a piece, including en passant
+func isCapture(board Board, piece Piece, move Move) bool {
+	if target, ok := board.At(move.To); ok && target.Colour != piece.Colour {
+		return true
+	}
+	return piece.PieceType == PAWN && board.EnPassant.canCapture(board, piece.Colour, move.To)
+}
+
+// checkSuffix returns '+' if a move puts the opponent in check, '#' if it
+// checkmates them, and nothing otherwise
+func checkSuffix(rs RuleSet, board Board, move Move) string {
+	newBoard, err := rs.ApplyMove(board, move)
+	if err != nil {
+		return ""
+	}
+	st := rs.Status(newBoard)
+	if st == CHECKMATE {
+		return "#"
+	} else if st == CHECK {
+		return "+"
+	}
+	return ""
+}
+
+// SAN renders a move in Standard Algebraic Notation, e.g. 'Nf3', 'exd5',
+// 'O-O', or 'e8=Q+'. The board is the board before the move was made.
+//
+// Chesseract moves use an extension of this notation: the destination is a
+// 4D position, e.g. 'Nb3n1'. If more than one piece of the same type can
+// move to that position, the origin is disambiguated by its x coordinate
+// (a-f), its z coordinate (m-r), or if neither is unique, the full
+// position. Pawn captures always include the x or z coordinate of the
+// origin, like in 2D chess.
+func SAN(rs RuleSet, board Board, move Move) string {
+	if _, ok := board.At(move.From); !ok && !move.IsDrop() {
+		return LongAlgebraic(rs, board, move)
+	}
+	return san(board, move, LegalMoves(rs, board)) + checkSuffix(rs, board, move)
+}
+
+// san renders a move in Standard Algebraic Notation, without the check
+// suffix. The legal moves are used for disambiguation.
+func san(board Board, move Move, legal []Move) string {
+	if move.IsDrop() {
+		return dropNotation(move)
+	}
+
+	piece, _ := board.At(move.From)
+
+	// Castling. In Chess960, the king moves onto its own rook.
+	if from, ok := move.From.(position2D); ok && piece.PieceType == KING {
+		dx := move.To.(position2D)[0] - from[0]
+		if rook, ok := board.At(move.To); ok && rook.Colour == piece.Colour {
+			dx *= 2
+		}
+		if dx >= 2 {
+			return "O-O"
+		} else if dx <= -2 {
+			return "O-O-O"
+		}
+	}
+
+	var sb strings.Builder
+	capture := isCapture(board, piece, move)
+
+	if piece.PieceType != PAWN {
+		sb.WriteString(piece.PieceType.Letter())
+	}
+
+	// Find all other pieces of the same type that can move to the same position
+	var rivals []Position
+	for _, mv := range legal {
+		if !mv.IsDrop() && mv.PieceType == piece.PieceType && mv.To.Equals(move.To) && !mv.From.Equals(move.From) {
+			rivals = append(rivals, mv.From)
+		}
+	}
+	if len(rivals) > 0 || (piece.PieceType == PAWN && capture) {
+		sb.WriteString(disambiguate(move.From, rivals))
+	}
+
+	if capture {
+		sb.WriteByte('x')
+	}
+	sb.WriteString(move.To.String())
+	if move.Promotion != 0 {
+		sb.WriteString("=" + move.Promotion.Letter())
+	}
+
+	return sb.String()
+}
+
+// disambiguators lists the ways of writing (part of) a position to tell it
+// apart from others, in order of preference
+func disambiguators(pos Position) []string {
+	if p, ok := pos.(position2D); ok {
+		return []string{
+			string('a' + rune(p[0])),
+			fmt.Sprintf("%d", p[1]+1),
+			pos.String(),
+		}
+	} else if p, ok := pos.(position3D); ok {
+		return []string{
+			string('a' + rune(p[0])),
+			string('A' + rune(p[2])),
+			pos.String(),
+		}
+	} else if p, ok := pos.(position4D); ok {
+		return []string{
+			string('a' + rune(p[0])),
+			string('m' + rune(p[2])),
+			pos.String(),
+		}
+	} else if p, ok := pos.(positionN); ok {
+		rv := make([]string, 0, p.dims+1)
+		for i := 0; i < p.dims; i++ {
+			rv = append(rv, p.coordinate(i))
+		}
+		return append(rv, pos.String())
+	}
+	return []string{pos.String()}
+}
+
+// disambiguate returns the shortest way of writing a position that tells it
+// apart from the other positions
+func disambiguate(pos Position, others []Position) string {
+	options := disambiguators(pos)
+	for i, opt := range options {
+		unique := true
+		for _, q := range others {
+			if disambiguators(q)[i] == opt {
+				unique = false
+			}
+		}
+		if unique {
+			return opt
+		}
+	}
+	return pos.String()
+}
+
+// normaliseSAN removes all optional parts of a move in Standard Algebraic
+// Notation, so that two ways of writing the same move compare equal
+func normaliseSAN(s string) string {
+	s = strings.TrimRight(s, "+#!?")
+	s = strings.ReplaceAll(s, "0", "O")
+	s = strings.ReplaceAll(s, "=", "")
+	s = strings.ReplaceAll(s, "x", "")
+	s = strings.ReplaceAll(s, ":", "")
+	if strings.HasPrefix(s, "@") {
+		// Pawn drops may omit the piece letter
+		s = "P" + s
+	}
+	return s
+}
+
+// ParseSAN decodes a move in Standard Algebraic Notation, by comparing it to
+// the notation of every legal move. Moves in long algebraic notation are
+// accepted as well.
+func ParseSAN(rs RuleSet, board Board, s string) (Move, error) {
+	want := normaliseSAN(s)
+	if want == "" {
+		return Move{}, fmt.Errorf("invalid move '%s'", s)
+	}
+
+	legal := LegalMoves(rs, board)
+	for _, mv := range legal {
+		if normaliseSAN(san(board, mv, legal)) == want {
+			return mv, nil
+		}
+	}
+
+	mv, err := ParseLongAlgebraic(rs, board, s)
+	if err != nil {
+		return Move{}, fmt.Errorf("invalid or illegal move '%s'", s)
+	}
+	return mv, nil
+}
@@ -0,0 +1,135 @@
+package chesseract
+
+import (
+	"testing"
+)
+
+func TestSAN(t *testing.T) {
+	rs := Boring2D{}
+
+	type testCase struct {
+		FEN      string
+		From, To string
+		Promo    PieceType
+		Expected string
+	}
+	cases := []testCase{
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "g1", "f3", 0, "Nf3"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "e2", "e4", 0, "e4"},
+		{"rnbqkbnr/ppp1pppp/8/3p4/4P3/8/PPPP1PPP/RNBQKBNR w KQkq d6 0 2", "e4", "d5", 0, "exd5"},
+		{"rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3", "e5", "f6", 0, "exf6"},
+		{"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", "e1", "g1", 0, "O-O"},
+		{"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", "e1", "c1", 0, "O-O-O"},
+		{"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", "e5", "f7", 0, "Nxf7"},
+		{"4k3/8/8/8/8/8/4K3/R6R w - - 0 1", "a1", "d1", 0, "Rad1"},
+		{"4k3/8/8/R7/8/8/4K3/R7 w - - 0 1", "a1", "a3", 0, "R1a3"},
+		{"4k3/8/8/8/Q1Q5/8/Q7/4K3 w - - 0 1", "a4", "b3", 0, "Qa4b3"},
+		{"k7/4P3/8/8/8/8/8/4K3 w - - 0 1", "e7", "e8", QUEEN, "e8=Q+"},
+		{"k7/4P3/8/8/8/8/8/4K3 w - - 0 1", "e7", "e8", KNIGHT, "e8=N"},
+		{"r1bqkbnr/pppp1ppp/2n5/4p3/2B1P3/5Q2/PPPP1PPP/RNB1K1NR w KQkq - 2 3", "f3", "f7", 0, "Qxf7#"},
+	}
+
+	for _, c := range cases {
+		board, err := ParseFEN(c.FEN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		from, _ := rs.ParsePosition(c.From)
+		to, _ := rs.ParsePosition(c.To)
+		piece, _ := board.At(from)
+		move := Move{PieceType: piece.PieceType, From: from, To: to, Promotion: c.Promo}
+
+		if s := SAN(rs, board, move); s != c.Expected {
+			t.Errorf("%s-%s in '%s' is written as '%s'; expected '%s'", c.From, c.To, c.FEN, s, c.Expected)
+		}
+
+		mv, err := ParseSAN(rs, board, c.Expected)
+		if err != nil {
+			t.Errorf("Error parsing '%s': %v", c.Expected, err)
+		} else if !mv.From.Equals(move.From) || !mv.To.Equals(move.To) || mv.Promotion != move.Promotion {
+			t.Errorf("'%s' is parsed as %s", c.Expected, mv)
+		}
+	}
+
+	// Optional parts of the notation can be left out or written differently
+	board, _ := ParseFEN(cases[4].FEN)
+	for _, s := range []string{"0-0", "O-O+", "Nf7", "Ne5xf7", "e5-f7"} {
+		if _, err := ParseSAN(rs, board, s); err != nil {
+			t.Errorf("Error parsing '%s': %v", s, err)
+		}
+	}
+	for _, s := range []string{"", "+", "Nf8", "Qe9", "Kf5"} {
+		if mv, err := ParseSAN(rs, board, s); err == nil {
+			if _, err := rs.ApplyMove(board, mv); err == nil {
+				t.Errorf("'%s' should not parse, but it is %s", s, mv)
+			}
+		}
+	}
+}
+
+func TestSAN4D(t *testing.T) {
+	rs := Chesseract{}
+	board := Board{
+		Pieces: []Piece{
+			{KING, WHITE, position4D{5, 5, 5, 5}},
+			{KING, BLACK, position4D{5, 5, 0, 5}},
+			{ROOK, WHITE, position4D{0, 0, 0, 0}},
+			{ROOK, WHITE, position4D{2, 0, 0, 0}},
+			{ROOK, WHITE, position4D{0, 0, 2, 0}},
+		},
+		Turn: WHITE,
+	}
+
+	cases := []struct {
+		From, To position4D
+		Expected string
+	}{
+		{position4D{0, 0, 0, 0}, position4D{1, 0, 0, 0}, "Rab1m1"},
+		{position4D{2, 0, 0, 0}, position4D{1, 0, 0, 0}, "Rcb1m1"},
+		{position4D{0, 0, 0, 0}, position4D{0, 0, 1, 0}, "Rma1n1"},
+		{position4D{0, 0, 2, 0}, position4D{0, 0, 1, 0}, "Roa1n1"},
+		{position4D{0, 0, 0, 0}, position4D{0, 1, 0, 0}, "Ra2m1"},
+	}
+	for _, c := range cases {
+		move := Move{PieceType: ROOK, From: c.From, To: c.To}
+		if s := SAN(rs, board, move); s != c.Expected {
+			t.Errorf("%s-%s is written as '%s'; expected '%s'", c.From, c.To, s, c.Expected)
+		}
+		mv, err := ParseSAN(rs, board, c.Expected)
+		if err != nil {
+			t.Errorf("Error parsing '%s': %v", c.Expected, err)
+		} else if !mv.From.Equals(move.From) || !mv.To.Equals(move.To) {
+			t.Errorf("'%s' is parsed as %s", c.Expected, mv)
+		}
+	}
+}
+
+func TestSANRoundTrip(t *testing.T) {
+	kiwipete, _ := ParseFEN("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	promotions, _ := ParseFEN("r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1")
+
+	matches := []Match{
+		{RuleSet: Boring2D{}, Board: Boring2D{}.DefaultBoard()},
+		{RuleSet: Boring2D{}, Board: kiwipete},
+		{RuleSet: Boring2D{}, Board: promotions},
+		{RuleSet: Chesseract{}, Board: Chesseract{}.DefaultBoard()},
+	}
+
+	for _, m := range matches {
+		seen := make(map[string]bool)
+		for _, move := range LegalMoves(m.RuleSet, m.Board) {
+			s := SAN(m.RuleSet, m.Board, move)
+			if seen[s] {
+				t.Errorf("Notation '%s' is ambiguous", s)
+			}
+			seen[s] = true
+
+			mv, err := ParseSAN(m.RuleSet, m.Board, s)
+			if err != nil {
+				t.Errorf("Error parsing '%s': %v", s, err)
+			} else if !mv.From.Equals(move.From) || !mv.To.Equals(move.To) || mv.Promotion != move.Promotion {
+				t.Errorf("'%s' is parsed as %s; expected %s", s, mv, move)
+			}
+		}
+	}
+}
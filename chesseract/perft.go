@@ -0,0 +1,25 @@
+package chesseract
+
+// Perft counts the number of leaf nodes in the tree of legal moves of the
+// specified depth, starting at this board. Comparing the outcome to known
+// values is a good way of catching bugs in move generation.
+func Perft(rs RuleSet, board Board, depth int) int {
+	if depth <= 0 {
+		return 1
+	}
+
+	moves := LegalMoves(rs, board)
+	if depth == 1 {
+		return len(moves)
+	}
+
+	rv := 0
+	for _, move := range moves {
+		newBoard, err := rs.ApplyMove(board, move)
+		if err != nil {
+			continue
+		}
+		rv += Perft(rs, newBoard, depth-1)
+	}
+	return rv
+}
@@ -0,0 +1,70 @@
+package chesseract
+
+import (
+	"testing"
+)
+
+func TestPerft(t *testing.T) {
+	type testCase struct {
+		FEN      string
+		Expected []int
+	}
+	cases := []testCase{
+		{
+			FEN:      "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+			Expected: []int{20, 400, 8902},
+		},
+		{
+			// "Kiwipete", which exercises castling, en passant and promotion
+			FEN:      "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+			Expected: []int{48, 2039},
+		},
+		{
+			FEN:      "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+			Expected: []int{14, 191, 2812},
+		},
+		{
+			FEN:      "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
+			Expected: []int{6, 264, 9467},
+		},
+	}
+
+	if !testing.Short() {
+		cases[0].Expected = append(cases[0].Expected, 197281)
+		cases[1].Expected = append(cases[1].Expected, 97862)
+	}
+
+	rs := Boring2D{}
+	for _, tc := range cases {
+		board, err := ParseFEN(tc.FEN)
+		if err != nil {
+			t.Errorf("%v", err)
+			continue
+		}
+		for i, expected := range tc.Expected {
+			if n := Perft(rs, board, i+1); n != expected {
+				t.Errorf("Perft(%d) of '%s' is %d; expected %d", i+1, tc.FEN, n, expected)
+			}
+		}
+	}
+
+	// Compare against the starting position
+	board, _ := ParseFEN(cases[0].FEN)
+	if n, m := Perft(rs, board, 2), Perft(rs, rs.DefaultBoard(), 2); n != m {
+		t.Errorf("Parsed starting position has perft %d, default board %d", n, m)
+	}
+}
+
+func TestHyperPerft(t *testing.T) {
+	rs := Chesseract{}
+	expected := []int{346}
+	if !testing.Short() {
+		expected = append(expected, 118718)
+	}
+
+	for i, n := range expected {
+		if m := Perft(rs, rs.DefaultBoard(), i+1); m != n {
+			t.Errorf("Perft(%d) of the default board is %d; expected %d", i+1, m, n)
+		}
+	}
+}
@@ -0,0 +1,420 @@
+package chesseract
+
+import (
+	"fmt"
+)
+
+func init() {
+	RegisterRuleSet("Raumschach", func() RuleSet {
+		return Raumschach{}
+	})
+}
+
+// A position3D represents a position on a 3D board, which consists of a
+// stack of 2D boards, or levels
+type position3D [3]int
+
+// String returns the position in the classic Raumschach notation: the level
+// (A-E), followed by the file and the rank, e.g. 'Cc3'
+func (p position3D) String() string {
+	return fmt.Sprintf("%c%c%d", 'A'+rune(p[2]), 'a'+rune(p[0]), p[1]+1)
+}
+
+func (p position3D) Equals(q Position) bool {
+	if q0, ok := q.(position3D); ok {
+		return p == q0
+	}
+	return false
+}
+
+func (p position3D) CellColour() Colour {
+	if (p[0]+p[1]+p[2])%2 == 0 {
+		return BLACK
+	} else {
+		return WHITE
+	}
+}
+
+func (p position3D) WorldPosition() (x, y, z float32) {
+	y = (float32(p[2]) - 2) * 3.6
+	x = float32(p[0]) - 2
+	z = float32(p[1]) - 2
+	return
+}
+
+// The Raumschach type implements Ferdinand Maack's 3D chess on a 5x5x5 board.
+// White starts on the bottom two levels, and black on the top two.
+type Raumschach struct{}
+
+func (Raumschach) String() string {
+	return "Raumschach"
+}
+
+func (Raumschach) PlayerColours() []Colour {
+	return []Colour{WHITE, BLACK}
+}
+
+// DefaultBoard sets up the initial board configuration
+func (Raumschach) DefaultBoard() Board {
+	return Board{
+		Pieces: []Piece{
+			// White pieces
+			{ROOK, WHITE, position3D{0, 0, 0}},
+			{KNIGHT, WHITE, position3D{1, 0, 0}},
+			{KING, WHITE, position3D{2, 0, 0}},
+			{KNIGHT, WHITE, position3D{3, 0, 0}},
+			{ROOK, WHITE, position3D{4, 0, 0}},
+			{PAWN, WHITE, position3D{0, 1, 0}},
+			{PAWN, WHITE, position3D{1, 1, 0}},
+			{PAWN, WHITE, position3D{2, 1, 0}},
+			{PAWN, WHITE, position3D{3, 1, 0}},
+			{PAWN, WHITE, position3D{4, 1, 0}},
+			{BISHOP, WHITE, position3D{0, 0, 1}},
+			{UNICORN, WHITE, position3D{1, 0, 1}},
+			{QUEEN, WHITE, position3D{2, 0, 1}},
+			{BISHOP, WHITE, position3D{3, 0, 1}},
+			{UNICORN, WHITE, position3D{4, 0, 1}},
+			{PAWN, WHITE, position3D{0, 1, 1}},
+			{PAWN, WHITE, position3D{1, 1, 1}},
+			{PAWN, WHITE, position3D{2, 1, 1}},
+			{PAWN, WHITE, position3D{3, 1, 1}},
+			{PAWN, WHITE, position3D{4, 1, 1}},
+			// Black pieces
+			{ROOK, BLACK, position3D{0, 4, 4}},
+			{KNIGHT, BLACK, position3D{1, 4, 4}},
+			{KING, BLACK, position3D{2, 4, 4}},
+			{KNIGHT, BLACK, position3D{3, 4, 4}},
+			{ROOK, BLACK, position3D{4, 4, 4}},
+			{PAWN, BLACK, position3D{0, 3, 4}},
+			{PAWN, BLACK, position3D{1, 3, 4}},
+			{PAWN, BLACK, position3D{2, 3, 4}},
+			{PAWN, BLACK, position3D{3, 3, 4}},
+			{PAWN, BLACK, position3D{4, 3, 4}},
+			{BISHOP, BLACK, position3D{0, 4, 3}},
+			{UNICORN, BLACK, position3D{1, 4, 3}},
+			{QUEEN, BLACK, position3D{2, 4, 3}},
+			{BISHOP, BLACK, position3D{3, 4, 3}},
+			{UNICORN, BLACK, position3D{4, 4, 3}},
+			{PAWN, BLACK, position3D{0, 3, 3}},
+			{PAWN, BLACK, position3D{1, 3, 3}},
+			{PAWN, BLACK, position3D{2, 3, 3}},
+			{PAWN, BLACK, position3D{3, 3, 3}},
+			{PAWN, BLACK, position3D{4, 3, 3}},
+		},
+		Turn: WHITE,
+	}
+}
+
+// AllPositions returns an iterator that allows one to range over all possible positions on the board in this variant
+func (Raumschach) AllPositions() []Position {
+	rv := make([]Position, 5*5*5)
+	for x := 0; x < 5; x++ {
+		for y := 0; y < 5; y++ {
+			for z := 0; z < 5; z++ {
+				rv[25*z+5*y+x] = position3D{x, y, z}
+			}
+		}
+	}
+	return rv
+}
+
+// ParsePosition converts a string representation into a Position of the correct type
+func (Raumschach) ParsePosition(s string) (Position, error) {
+	if len(s) != 3 {
+		return invalidPosition{}, errInvalidFormat
+	}
+
+	rv := position3D{int(s[1] - 'a'), int(s[2] - '1'), int(s[0] - 'A')}
+	for _, c := range rv {
+		if c < 0 || c >= 5 {
+			return invalidPosition{}, errInvalidFormat
+		}
+	}
+	return rv, nil
+}
+
+// CanMove tests whether a piece can move to the specified new position on the board.
+// Note: this only tests movement rules; the check check is performed elsewhere.
+func (Raumschach) CanMove(board Board, piece Piece, pos Position) bool {
+	var oldPos, newPos position3D
+	var ok bool
+	if oldPos, ok = piece.Position.(position3D); !ok {
+		return false
+	}
+	if newPos, ok = pos.(position3D); !ok {
+		return false
+	}
+
+	// Check board boundaries
+	for _, c := range newPos {
+		if c < 0 || c >= 5 {
+			return false
+		}
+	}
+
+	// Pieces have to move
+	var d position3D
+	moved := 0
+	for i := range d {
+		d[i] = newPos[i] - oldPos[i]
+		if d[i] != 0 {
+			moved++
+		}
+	}
+	if moved == 0 {
+		return false
+	}
+
+	capture := false
+
+	// You can't capture your own pieces
+	if op, ok := board.At(newPos); ok {
+		if op.Colour == piece.Colour {
+			return false
+		} else {
+			capture = true
+		}
+	}
+
+	if piece.PieceType == KING {
+		// Move one square in any direction
+		for _, c := range d {
+			if c*c > 1 {
+				return false
+			}
+		}
+
+		return true
+	} else if piece.PieceType == QUEEN {
+		// Straight, diagonal, or triagonal
+		if moved != 1 && !isLine3d(d) {
+			return false
+		}
+	} else if piece.PieceType == BISHOP {
+		// Diagonal across two axes
+		if moved != 2 || !isLine3d(d) {
+			return false
+		}
+	} else if piece.PieceType == UNICORN {
+		// Triagonal across all three axes
+		if moved != 3 || !isLine3d(d) {
+			return false
+		}
+	} else if piece.PieceType == KNIGHT {
+		// Horsin' around in one of the three planes
+		if moved != 2 {
+			return false
+		}
+		one, two := 0, 0
+		for _, c := range d {
+			if c*c == 1 {
+				one++
+			} else if c*c == 4 {
+				two++
+			}
+		}
+		return one == 1 && two == 1
+	} else if piece.PieceType == ROOK {
+		// Straight only
+		if moved != 1 {
+			return false
+		}
+	} else if piece.PieceType == PAWN {
+		// White pawns advance forward and upward; black pawns go the other way
+		dir := 1
+		if piece.Colour == BLACK {
+			dir = -1
+		}
+
+		// A pawn advances one square along exactly one of the forward axes
+		if d[1]*dir < 0 || d[2]*dir < 0 || d[1]*d[1]+d[2]*d[2] != 1 {
+			return false
+		}
+
+		if capture {
+			// Capture one square forward, and one square sideways
+			return d[0]*d[0] == 1
+		}
+		return d[0] == 0
+	} else {
+		// Unknown piece
+		return false
+	}
+
+	// Check the trajectory in between
+	v, r := normalise3d(d)
+	for i := 1; i < r; i++ {
+		p := position3D{oldPos[0] + i*v[0], oldPos[1] + i*v[1], oldPos[2] + i*v[2]}
+		if _, ok := board.At(p); ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LegalMoves returns all legal moves for the player whose turn it is
+func (rs Raumschach) LegalMoves(board Board) []Move {
+	return legalMoves(rs, board, func(p Piece) []Position {
+		return rs.targets(board, p)
+	})
+}
+
+var straight3D, diagonal3D, triagonal3D, knight3D, king3D = spaceVectors()
+
+// spaceVectors computes the unit vectors in every direction each piece type can move in
+func spaceVectors() (straight, diagonal, triagonal, knight, king []position3D) {
+	var d position3D
+	for d[0] = -2; d[0] <= 2; d[0]++ {
+		for d[1] = -2; d[1] <= 2; d[1]++ {
+			for d[2] = -2; d[2] <= 2; d[2]++ {
+				ones, twos := 0, 0
+				for _, c := range d {
+					if c*c == 1 {
+						ones++
+					} else if c*c == 4 {
+						twos++
+					}
+				}
+				if twos == 0 && ones > 0 {
+					king = append(king, d)
+				}
+				if twos == 0 && ones == 1 {
+					straight = append(straight, d)
+				} else if twos == 0 && ones == 2 {
+					diagonal = append(diagonal, d)
+				} else if twos == 0 && ones == 3 {
+					triagonal = append(triagonal, d)
+				} else if twos == 1 && ones == 1 {
+					knight = append(knight, d)
+				}
+			}
+		}
+	}
+	return
+}
+
+// targets lists the positions a piece could possibly move to. The finer
+// points of the movement rules are left to CanMove.
+func (Raumschach) targets(board Board, piece Piece) []Position {
+	pos, ok := piece.Position.(position3D)
+	if !ok {
+		return nil
+	}
+
+	var rv []Position
+	onBoard := func(p position3D) bool {
+		return p[0] >= 0 && p[0] < 5 && p[1] >= 0 && p[1] < 5 && p[2] >= 0 && p[2] < 5
+	}
+	jump := func(vectors []position3D) {
+		for _, v := range vectors {
+			p := position3D{pos[0] + v[0], pos[1] + v[1], pos[2] + v[2]}
+			if onBoard(p) {
+				rv = append(rv, p)
+			}
+		}
+	}
+	slide := func(vectors []position3D) {
+		for _, v := range vectors {
+			for p := (position3D{pos[0] + v[0], pos[1] + v[1], pos[2] + v[2]}); onBoard(p); p = (position3D{p[0] + v[0], p[1] + v[1], p[2] + v[2]}) {
+				rv = append(rv, p)
+				if _, ok := board.At(p); ok {
+					break
+				}
+			}
+		}
+	}
+
+	if piece.PieceType == KING {
+		jump(king3D)
+	} else if piece.PieceType == QUEEN {
+		slide(straight3D)
+		slide(diagonal3D)
+		slide(triagonal3D)
+	} else if piece.PieceType == BISHOP {
+		slide(diagonal3D)
+	} else if piece.PieceType == UNICORN {
+		slide(triagonal3D)
+	} else if piece.PieceType == KNIGHT {
+		jump(knight3D)
+	} else if piece.PieceType == ROOK {
+		slide(straight3D)
+	} else if piece.PieceType == PAWN {
+		dir := 1
+		if piece.Colour == BLACK {
+			dir = -1
+		}
+		jump([]position3D{{0, dir, 0}, {1, dir, 0}, {-1, dir, 0}, {0, 0, dir}, {1, 0, dir}, {-1, 0, dir}})
+	}
+
+	return rv
+}
+
+// isLine3d tests if a displacement changes every coordinate it changes by the
+// same amount, i.e. it lies on a straight line, a diagonal, or a triagonal
+func isLine3d(d position3D) bool {
+	r := 0
+	for _, c := range d {
+		if c < 0 {
+			c = -c
+		}
+		if c == 0 {
+			continue
+		}
+		if r != 0 && c != r {
+			return false
+		}
+		r = c
+	}
+	return r != 0
+}
+
+func normalise3d(d position3D) (v position3D, r int) {
+	for i, c := range d {
+		if c < 0 {
+			v[i] = -1
+			r = -1 * c
+		} else if c > 0 {
+			v[i] = 1
+			r = c
+		}
+	}
+	return
+}
+
+// ApplyMove performs a move on the board, and returns the resulting board
+func (rs Raumschach) ApplyMove(board Board, move Move) (Board, error) {
+	piece, ok := board.At(move.From)
+	if !ok {
+		return Board{}, errIllegalMove
+	}
+
+	if !rs.CanMove(board, piece, move.To) {
+		return Board{}, errIllegalMove
+	}
+
+	newBoard := board.movePiece(move)
+
+	// Pawns reaching the last rank on the opponent's back level get promoted
+	if to := move.To.(position3D); piece.PieceType == PAWN && ((to[1] == 4 && to[2] == 4) || (to[1] == 0 && to[2] == 0)) {
+		if !validPromotion(move.Promotion) && move.Promotion != UNICORN {
+			return Board{}, errIllegalMove
+		}
+		newBoard = newBoard.promote(move.To, move.Promotion)
+	} else if move.Promotion != 0 {
+		return Board{}, errIllegalMove
+	}
+
+	if inCheck(rs, newBoard, piece.Colour) {
+		return Board{}, errIllegalMove
+	}
+
+	newBoard = newBoard.nextTurn(rs.PlayerColours())
+
+	return newBoard, nil
+}
+
+// Status determines whether the player whose turn it is is in check, checkmate, or stalemate
+func (rs Raumschach) Status(board Board) Status {
+	return gameStatus(rs, board)
+}
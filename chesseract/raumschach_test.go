@@ -0,0 +1,260 @@
+package chesseract
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRaumschachPositionParser(t *testing.T) {
+	rs := Raumschach{}
+	invalidValues := []string{
+		"",
+		"e2",
+		"a1m1",
+		"Fa1",
+		"Af1",
+		"Aa6",
+		"Aa0",
+		"aa1",
+	}
+	for _, s := range invalidValues {
+		p, err := rs.ParsePosition(s)
+		if err == nil {
+			t.Errorf("String '%s' decodes into '%s' - not good", s, p)
+		}
+	}
+
+	// Test every valid value
+	for _, p := range rs.AllPositions() {
+		q, err := rs.ParsePosition(p.String())
+		if err != nil {
+			t.Errorf("Error parsing position '%s': %v", p, err)
+		} else if !p.Equals(q) {
+			t.Errorf("Position '%s' turns into '%s'", p, q)
+		}
+	}
+
+	if s := (position3D{2, 0, 1}).String(); s != "Bc1" {
+		t.Errorf("The white queen starts at '%s'", s)
+	}
+}
+
+func TestRaumschachDefaultBoard(t *testing.T) {
+	rs := Raumschach{}
+	board := rs.DefaultBoard()
+
+	count := make(map[PieceType]int)
+	colours := make(map[Colour]int)
+	for _, p := range rs.AllPositions() {
+		if pc, ok := board.At(p); ok {
+			count[pc.PieceType]++
+			colours[pc.Colour]++
+		}
+	}
+
+	if colours[WHITE] != 20 || colours[BLACK] != 20 || count[PAWN] != 20 || count[UNICORN] != 4 || count[KING] != 2 {
+		t.Errorf("Unexpected default board: %v %v", count, colours)
+	}
+
+	if st := rs.Status(board); st != NORMAL {
+		t.Errorf("Unexpected status '%s' for the default board", st)
+	}
+}
+
+func TestRaumschachMovementRules(t *testing.T) {
+	rs := Raumschach{}
+
+	type testCase struct {
+		PieceIndex               int
+		ExpectedReachableSquares int
+	}
+	type testSuite struct {
+		Board Board
+		Cases []testCase
+	}
+
+	suite := []testSuite{
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{ROOK, WHITE, position3D{2, 2, 2}},
+				},
+			},
+			Cases: []testCase{
+				{0, 12},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{BISHOP, WHITE, position3D{2, 2, 2}},
+				},
+			},
+			Cases: []testCase{
+				{0, 24},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{UNICORN, WHITE, position3D{2, 2, 2}},
+					{UNICORN, BLACK, position3D{0, 0, 1}},
+				},
+			},
+			Cases: []testCase{
+				{0, 16},
+				{1, 4},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{QUEEN, BLACK, position3D{2, 2, 2}},
+				},
+			},
+			Cases: []testCase{
+				{0, 52},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{KING, WHITE, position3D{2, 2, 2}},
+					{KING, BLACK, position3D{0, 0, 0}},
+				},
+			},
+			Cases: []testCase{
+				{0, 26},
+				{1, 7},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{KNIGHT, WHITE, position3D{2, 2, 2}},
+					{KNIGHT, WHITE, position3D{0, 0, 0}},
+				},
+			},
+			Cases: []testCase{
+				{0, 24},
+				{1, 6},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{ROOK, WHITE, position3D{0, 0, 0}},
+					{PAWN, WHITE, position3D{2, 0, 0}},
+					{PAWN, BLACK, position3D{0, 0, 3}},
+				},
+			},
+			Cases: []testCase{
+				{0, 8},
+			},
+		},
+		{
+			Board: Board{
+				Pieces: []Piece{
+					{PAWN, WHITE, position3D{2, 1, 1}},
+					{PAWN, BLACK, position3D{1, 2, 1}},
+					{PAWN, BLACK, position3D{3, 1, 2}},
+					{PAWN, BLACK, position3D{2, 2, 2}},
+				},
+			},
+			Cases: []testCase{
+				{0, 4},
+				{1, 3},
+				{3, 2},
+			},
+		},
+	}
+
+	for _, ts := range suite {
+		for _, tc := range ts.Cases {
+			hl := []Position{}
+			piece := ts.Board.Pieces[tc.PieceIndex]
+			for _, p := range rs.AllPositions() {
+				if rs.CanMove(ts.Board, piece, p) {
+					hl = append(hl, p)
+				}
+			}
+			if len(hl) != tc.ExpectedReachableSquares {
+				t.Errorf("Expected piece at %s to be able to move to %d squares, but measured %d", piece.Position, tc.ExpectedReachableSquares, len(hl))
+				for _, p := range hl {
+					t.Logf("    %s", p)
+				}
+			}
+		}
+	}
+}
+
+func TestRaumschachMatch(t *testing.T) {
+	rs := Raumschach{}
+	match := Match{
+		RuleSet: rs,
+		Board:   rs.DefaultBoard(),
+	}
+
+	for _, s := range []string{"Bc3", "Dc3", "NbAc3", "Dc4", "Cc3", "UCc4"} {
+		move, err := ParseSAN(rs, match.Board, s)
+		if err != nil {
+			t.Fatalf("error parsing '%s': %v", s, err)
+		}
+		if san := SAN(rs, match.Board, move); san != s {
+			t.Errorf("Move '%s' is written as '%s'", s, san)
+		}
+		newBoard, err := rs.ApplyMove(match.Board, move)
+		if err != nil {
+			t.Fatalf("applying move '%s': %v", s, err)
+		}
+		match.Moves = append(match.Moves, move)
+		match.Board = newBoard
+	}
+
+	var buf bytes.Buffer
+	match.DebugDump(&buf, nil)
+	t.Logf("%s", buf.String())
+
+	if len(LegalMoves(rs, match.Board)) == 0 {
+		t.Errorf("White has no moves")
+	}
+
+	if mv, err := ParseLongAlgebraic(rs, match.Board, "NAc3-Ab5"); err != nil || mv.PieceType != KNIGHT {
+		t.Errorf("Long algebraic notation gives %v, %v", mv, err)
+	}
+	if mv, err := ParseLongAlgebraic(rs, match.Board, "Cc3Cc4"); err != nil || mv.PieceType != PAWN {
+		t.Errorf("Long algebraic notation gives %v, %v", mv, err)
+	}
+}
+
+func TestRaumschachPromotion(t *testing.T) {
+	rs := Raumschach{}
+	board := Board{
+		Pieces: []Piece{
+			{KING, WHITE, position3D{0, 0, 0}},
+			{KING, BLACK, position3D{0, 0, 4}},
+			{PAWN, WHITE, position3D{2, 3, 4}},
+			{PAWN, WHITE, position3D{3, 4, 3}},
+		},
+		Turn: WHITE,
+	}
+
+	if _, err := rs.ApplyMove(board, Move{PAWN, position3D{2, 3, 4}, position3D{2, 4, 4}, 0, 0}); err == nil {
+		t.Errorf("A pawn reaching the last rank of the top level must be promoted")
+	}
+	newBoard, err := rs.ApplyMove(board, Move{PAWN, position3D{2, 3, 4}, position3D{2, 4, 4}, UNICORN, 0})
+	if err != nil {
+		t.Fatalf("error promoting pawn: %v", err)
+	}
+	if pc, _ := newBoard.At(position3D{2, 4, 4}); pc.PieceType != UNICORN {
+		t.Errorf("Pawn turned into %s", pc.PieceType)
+	}
+
+	// Reaching the last rank on a lower level isn't enough
+	if _, err := rs.ApplyMove(board, Move{PAWN, position3D{3, 4, 3}, position3D{3, 4, 4}, 0, 0}); err == nil {
+		t.Errorf("A pawn reaching the top level on the last rank must be promoted")
+	}
+	if _, err := rs.ApplyMove(board, Move{PAWN, position3D{3, 4, 3}, position3D{3, 4, 4}, QUEEN, 0}); err != nil {
+		t.Errorf("error promoting pawn: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package uci
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thijzert/chesseract/chesseract"
+)
+
+// MoveString renders a move the way UCI expects it, e.g. 'e2e4' or 'e7e8q'.
+// Castling is written as the king's move, e.g. 'e1g1'.
+func MoveString(move chesseract.Move) string {
+	if move.From == nil || move.To == nil {
+		return "0000"
+	}
+	rv := move.From.String() + move.To.String()
+	if move.Promotion != 0 {
+		rv += strings.ToLower(move.Promotion.Letter())
+	}
+	return rv
+}
+
+// ParseMove decodes a move in UCI notation, and checks that it's legal on the
+// specified board
+func ParseMove(rs chesseract.RuleSet, board chesseract.Board, s string) (chesseract.Move, error) {
+	if len(s) != 4 && len(s) != 5 {
+		return chesseract.Move{}, fmt.Errorf("invalid move '%s'", s)
+	}
+
+	rv, err := chesseract.ParseLongAlgebraic(rs, board, s[:4])
+	if err != nil {
+		return chesseract.Move{}, err
+	}
+	if len(s) == 5 {
+		rv.Promotion, err = chesseract.ParsePieceType(s[4:])
+		if err != nil {
+			return chesseract.Move{}, fmt.Errorf("invalid promotion in move '%s'", s)
+		}
+	}
+
+	if _, err := rs.ApplyMove(board, rv); err != nil {
+		return chesseract.Move{}, fmt.Errorf("illegal move '%s': %v", s, err)
+	}
+	return rv, nil
+}
@@ -0,0 +1,280 @@
+package uci
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thijzert/chesseract/chesseract"
+	"github.com/thijzert/chesseract/chesseract/engine"
+)
+
+// ErrUnsupportedRuleSet is returned when an engine is asked to play anything
+// other than the old 2D rules
+var ErrUnsupportedRuleSet = errors.New("UCI engines only support Boring2D")
+
+// ErrEngineExited is returned when the engine process stops unexpectedly
+var ErrEngineExited = errors.New("UCI engine exited")
+
+// An Analysis contains the result of a search by a UCI engine
+type Analysis struct {
+	// BestMove is the move the engine recommends
+	BestMove chesseract.Move
+
+	// Depth is the deepest search depth the engine reported
+	Depth int
+
+	// Score is the evaluation of the position from the perspective of the
+	// player whose turn it is, in centipawns
+	Score int
+
+	// Mate is the number of moves until checkmate, if the engine found one.
+	// It is negative if the player whose turn it is is getting mated.
+	Mate int
+}
+
+// An Engine is a UCI chess engine running in a child process. An Engine can
+// only perform one search at a time; concurrent searches wait their turn.
+type Engine struct {
+	// Name is the name the engine reports for itself
+	Name string
+
+	config engine.Config
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	lines  chan string
+	mu     sync.Mutex
+}
+
+// Start launches a UCI engine, and waits until it's ready to play. The
+// configuration sets the search depth and time per move; if both are zero,
+// the engine searches to a depth of 3.
+func Start(ctx context.Context, config engine.Config, path string, args ...string) (*Engine, error) {
+	cmd := exec.Command(path, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	e := &Engine{
+		config: config,
+		cmd:    cmd,
+		stdin:  stdin,
+		lines:  make(chan string, 64),
+	}
+	go e.readLines(stdout)
+
+	if err := e.handshake(ctx); err != nil {
+		e.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+// handshake initialises the UCI protocol
+func (e *Engine) handshake(ctx context.Context) error {
+	if err := e.send("uci"); err != nil {
+		return err
+	}
+	for {
+		line, err := e.readLine(ctx)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(line, "id name ") {
+			e.Name = strings.TrimPrefix(line, "id name ")
+		} else if line == "uciok" {
+			break
+		}
+	}
+
+	if err := e.send("ucinewgame"); err != nil {
+		return err
+	}
+	return e.waitReady(ctx)
+}
+
+// waitReady waits until the engine has processed all previous commands
+func (e *Engine) waitReady(ctx context.Context) error {
+	if err := e.send("isready"); err != nil {
+		return err
+	}
+	for {
+		line, err := e.readLine(ctx)
+		if err != nil {
+			return err
+		}
+		if line == "readyok" {
+			return nil
+		}
+	}
+}
+
+// readLines forwards the engine's output to the lines channel, one line at a
+// time. The channel is closed once the engine exits.
+func (e *Engine) readLines(r io.Reader) {
+	defer close(e.lines)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		e.lines <- strings.TrimSpace(scanner.Text())
+	}
+}
+
+// readLine returns the next line of output
+func (e *Engine) readLine(ctx context.Context) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case line, ok := <-e.lines:
+		if !ok {
+			return "", ErrEngineExited
+		}
+		return line, nil
+	}
+}
+
+// send writes a command to the engine
+func (e *Engine) send(command string) error {
+	_, err := fmt.Fprintln(e.stdin, command)
+	return err
+}
+
+// Close stops the engine process
+func (e *Engine) Close() error {
+	e.send("quit")
+	e.stdin.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- e.cmd.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(2 * time.Second):
+		e.cmd.Process.Kill()
+		return <-done
+	}
+}
+
+// BestMove asks the engine for the best move for the player whose turn it is
+func (e *Engine) BestMove(ctx context.Context, rs chesseract.RuleSet, board chesseract.Board) (chesseract.Move, error) {
+	a, err := e.Analyse(ctx, rs, board)
+	if err != nil {
+		return chesseract.Move{}, err
+	}
+	return a.BestMove, nil
+}
+
+// Analyse lets the engine search the position, and returns its verdict
+func (e *Engine) Analyse(ctx context.Context, rs chesseract.RuleSet, board chesseract.Board) (Analysis, error) {
+	var rv Analysis
+	if _, ok := rs.(chesseract.Boring2D); !ok {
+		return rv, ErrUnsupportedRuleSet
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.send("position fen " + board.FEN()); err != nil {
+		return rv, err
+	}
+	if err := e.send(e.goCommand()); err != nil {
+		return rv, err
+	}
+
+	stopped := false
+	for {
+		var line string
+		var err error
+		if stopped {
+			// Give the engine a moment to respond to the stop command
+			waitCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			line, err = e.readLine(waitCtx)
+			cancel()
+		} else {
+			line, err = e.readLine(ctx)
+		}
+
+		if err != nil && !stopped && ctx.Err() != nil {
+			e.send("stop")
+			stopped = true
+			continue
+		} else if err != nil && stopped {
+			return rv, ctx.Err()
+		} else if err != nil {
+			return rv, err
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "info" {
+			parseInfo(&rv, fields[1:])
+		} else if fields[0] == "bestmove" {
+			if stopped {
+				return rv, ctx.Err()
+			}
+			if len(fields) < 2 || fields[1] == "(none)" || fields[1] == "0000" {
+				return rv, engine.ErrNoMoves
+			}
+			rv.BestMove, err = ParseMove(rs, board, fields[1])
+			return rv, err
+		}
+	}
+}
+
+// goCommand returns the command that starts a search
+func (e *Engine) goCommand() string {
+	rv := "go"
+	depth := e.config.Depth
+	if depth <= 0 && e.config.MoveTime <= 0 {
+		depth = 3
+	}
+	if depth > 0 {
+		rv += fmt.Sprintf(" depth %d", depth)
+	}
+	if e.config.MoveTime > 0 {
+		rv += fmt.Sprintf(" movetime %d", e.config.MoveTime.Milliseconds())
+	}
+	return rv
+}
+
+// parseInfo reads the search depth and score from an info line
+func parseInfo(a *Analysis, fields []string) {
+	for i := 0; i+1 < len(fields); i++ {
+		if fields[i] == "depth" {
+			if n, err := strconv.Atoi(fields[i+1]); err == nil {
+				a.Depth = n
+			}
+		} else if fields[i] == "score" && i+2 < len(fields) {
+			n, err := strconv.Atoi(fields[i+2])
+			if err != nil {
+				continue
+			}
+			if fields[i+1] == "cp" {
+				a.Score, a.Mate = n, 0
+			} else if fields[i+1] == "mate" {
+				a.Mate = n
+			}
+		} else if fields[i] == "pv" {
+			// The principal variation runs to the end of the line
+			return
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package uci
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thijzert/chesseract/chesseract"
+	"github.com/thijzert/chesseract/chesseract/engine"
+)
+
+// TestMain doubles as a fake UCI engine if the test binary is started with
+// the environment variable below set
+func TestMain(m *testing.M) {
+	if os.Getenv("CHESSERACT_FAKE_UCI") != "" {
+		fakeEngine(os.Getenv("CHESSERACT_FAKE_UCI"))
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// fakeEngine speaks just enough UCI to play: it always picks the first legal
+// move. In 'slow' mode, it doesn't respond to 'go' until told to stop.
+func fakeEngine(mode string) {
+	rs := chesseract.Boring2D{}
+	board := rs.DefaultBoard()
+	var pending string
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "uci" {
+			fmt.Println("id name Fake Engine")
+			fmt.Println("id author nobody")
+			fmt.Println("uciok")
+		} else if fields[0] == "isready" {
+			fmt.Println("readyok")
+		} else if fields[0] == "position" && len(fields) > 2 && fields[1] == "fen" {
+			board, _ = chesseract.ParseFEN(strings.Join(fields[2:], " "))
+		} else if fields[0] == "go" {
+			pending = "bestmove (none)"
+			if moves := chesseract.LegalMoves(rs, board); len(moves) > 0 {
+				fmt.Println("info depth 1 score cp 17 pv " + MoveString(moves[0]))
+				pending = "bestmove " + MoveString(moves[0])
+			}
+			if mode != "slow" {
+				fmt.Println(pending)
+			}
+		} else if fields[0] == "stop" {
+			fmt.Println(pending)
+		} else if fields[0] == "quit" {
+			return
+		}
+	}
+}
+
+// startFake starts the test binary as a fake UCI engine
+func startFake(t *testing.T, mode string) *Engine {
+	os.Setenv("CHESSERACT_FAKE_UCI", mode)
+	defer os.Unsetenv("CHESSERACT_FAKE_UCI")
+
+	e, err := Start(context.Background(), engine.Config{Depth: 1}, os.Args[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestMoveString(t *testing.T) {
+	rs := chesseract.Boring2D{}
+	for fen, moves := range map[string][]string{
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1": {"e2e4", "g1f3"},
+		"4k3/1P6/8/8/8/8/8/4K2R w K - 0 1":                         {"b7b8q", "b7b8n", "e1g1"},
+	} {
+		board, err := chesseract.ParseFEN(fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range moves {
+			move, err := ParseMove(rs, board, s)
+			if err != nil {
+				t.Errorf("%s: %v", s, err)
+				continue
+			}
+			if rv := MoveString(move); rv != s {
+				t.Errorf("Expected %s; got %s", s, rv)
+			}
+		}
+	}
+
+	for _, s := range []string{"e2e5", "e7e5", "e2", "e2e4x", "b1b3"} {
+		if _, err := ParseMove(rs, rs.DefaultBoard(), s); err == nil {
+			t.Errorf("Move '%s' should be rejected", s)
+		}
+	}
+}
+
+func TestEngine(t *testing.T) {
+	e := startFake(t, "")
+	defer e.Close()
+
+	if e.Name != "Fake Engine" {
+		t.Errorf("Unexpected engine name '%s'", e.Name)
+	}
+
+	rs := chesseract.Boring2D{}
+	board := rs.DefaultBoard()
+	for i := 0; i < 6; i++ {
+		a, err := e.Analyse(context.Background(), rs, board)
+		if err != nil {
+			t.Fatalf("Ply %d: %v", i, err)
+		}
+		if a.Depth != 1 || a.Score != 17 {
+			t.Errorf("Unexpected analysis %+v", a)
+		}
+		board, err = rs.ApplyMove(board, a.BestMove)
+		if err != nil {
+			t.Fatalf("Ply %d: %v", i, err)
+		}
+	}
+
+	board, _ = chesseract.ParseFEN("R5k1/5ppp/8/8/8/8/8/6K1 b - - 0 1")
+	if _, err := e.BestMove(context.Background(), rs, board); err != engine.ErrNoMoves {
+		t.Errorf("Expected ErrNoMoves; got %v", err)
+	}
+
+	if _, err := e.BestMove(context.Background(), chesseract.Chesseract{}, board); err != ErrUnsupportedRuleSet {
+		t.Errorf("Expected ErrUnsupportedRuleSet; got %v", err)
+	}
+}
+
+func TestEngineCancel(t *testing.T) {
+	e := startFake(t, "slow")
+	defer e.Close()
+
+	rs := chesseract.Boring2D{}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := e.BestMove(ctx, rs, rs.DefaultBoard()); err != context.DeadlineExceeded {
+		t.Errorf("Expected the search to time out; got %v", err)
+	}
+
+	// The engine should still be usable afterwards
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := e.BestMove(ctx, rs, rs.DefaultBoard()); err != context.DeadlineExceeded {
+		t.Errorf("Expected the search to time out; got %v", err)
+	}
+}
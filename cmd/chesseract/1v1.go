@@ -41,17 +41,26 @@ func (o *oneVoneServer) Run(ctx context.Context) {
 }
 
 func (s *oneVoneServer) SubmitMove(c *oneVoneClient, _ *game.Game, m chesseract.Move) error {
-	pat, ok := s.Game.Match.Board.At(m.From)
-	if !ok {
-		return client.ErrIllegalMove
+	if s.Game.Result != nil {
+		return client.ErrGameHasFinished
+	}
+
+	// Drops always use a piece of the player whose turn it is
+	colour := s.Game.Match.Board.Turn
+	if !m.IsDrop() {
+		pat, ok := s.Game.Match.Board.At(m.From)
+		if !ok {
+			return client.ErrIllegalMove
+		}
+		colour = pat.Colour
 	}
 
 	if c == s.B {
-		if pat.Colour != chesseract.BLACK || s.Game.Match.Board.Turn != chesseract.BLACK {
+		if colour != chesseract.BLACK || s.Game.Match.Board.Turn != chesseract.BLACK {
 			return client.ErrNotYourTurn
 		}
 	} else if c == s.W {
-		if pat.Colour != chesseract.WHITE || s.Game.Match.Board.Turn != chesseract.WHITE {
+		if colour != chesseract.WHITE || s.Game.Match.Board.Turn != chesseract.WHITE {
 			return client.ErrNotYourTurn
 		}
 	} else {
@@ -71,6 +80,7 @@ func (s *oneVoneServer) SubmitMove(c *oneVoneClient, _ *game.Game, m chesseract.
 
 	s.Game.Match.Board = newb
 	s.Game.Match.Moves = append(s.Game.Match.Moves, m)
+	s.Game.Result = s.Game.Match.Result()
 
 	s.B.movesIn <- m
 	s.W.movesIn <- m
@@ -154,6 +164,11 @@ func (o *oneVoneClient) ActiveGames(context.Context) ([]client.GameSession, erro
 		o.game.Match.RuleSet = o.server.Game.Match.RuleSet
 		o.game.Match.Board.Turn = o.server.Game.Match.Board.Turn
 		o.game.Match.Board.Pieces = append(o.game.Match.Board.Pieces, o.server.Game.Match.Board.Pieces...)
+		o.game.Match.Board.Castling = append(o.game.Match.Board.Castling, o.server.Game.Match.Board.Castling...)
+		o.game.Match.Board.EnPassant = o.server.Game.Match.Board.EnPassant
+		o.game.Match.Board.HalfMoveClock = o.server.Game.Match.Board.HalfMoveClock
+		o.game.Match.Board.FullMoves = o.server.Game.Match.Board.FullMoves
+		o.game.Match.StartingBoard = o.server.Game.Match.StartingBoard
 		o.game.Match.StartTime = o.server.Game.Match.StartTime
 		o.game.Match.Moves = append(o.game.Match.Moves, o.server.Game.Match.Moves...)
 	}
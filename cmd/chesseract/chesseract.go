@@ -35,7 +35,7 @@ func main() {
 	}
 
 	var err error = fmt.Errorf("invalid command")
-	if command == "" {
+	if command == "" || command == "local" {
 		err = consoleLocalMultiplayer(&conf, args)
 	} else if command == "server" {
 		err = apiServer(&conf, args)
@@ -43,6 +43,8 @@ func main() {
 		err = consoleGame(&conf, args)
 	} else if command == "glclient" {
 		err = glGame(&conf, args)
+	} else if command == "perft" {
+		err = perftCommand(&conf, args)
 	}
 
 	er = saveConfig(conf, configLocation)
@@ -1,29 +1,37 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/thijzert/chesseract/chesseract"
 	"github.com/thijzert/chesseract/chesseract/client"
 	"github.com/thijzert/chesseract/chesseract/client/httpclient"
+	"github.com/thijzert/chesseract/chesseract/engine"
 	"github.com/thijzert/chesseract/chesseract/game"
+	"github.com/thijzert/chesseract/chesseract/uci"
 )
 
 var consoleMutex sync.Mutex
 
+var stdin = bufio.NewReader(os.Stdin)
+
 func consoleGame(conf *Config, args []string) error {
 	logVerbose := false
 	clientConf := httpclient.ClientConfig{}
-	var ruleset string
+	var ruleset, playerNames string
 
 	consoleSettings := flag.NewFlagSet("consoleClient", flag.ContinueOnError)
 	consoleSettings.StringVar(&clientConf.ServerURI, "server", "", "URI to multiplayer server")
 	consoleSettings.StringVar(&clientConf.Username, "username", "", "Online username")
 	consoleSettings.StringVar(&ruleset, "ruleset", "Chesseract", "Rule set to use for new games")
+	consoleSettings.StringVar(&playerNames, "players", "alice,bob", "Comma-separated list of players in new games, in turn order")
 	consoleSettings.BoolVar(&logVerbose, "v", false, "Verbosely log all requests")
 	err := consoleSettings.Parse(args)
 	if err != nil {
@@ -58,10 +66,11 @@ func consoleGame(conf *Config, args []string) error {
 	if len(ag) > 0 {
 		g = ag[0]
 	} else {
-		g, err = c.NewGame(ctx, rs, []game.Player{
-			{Name: "alice"},
-			{Name: "bob"},
-		})
+		var players []game.Player
+		for _, name := range strings.Split(playerNames, ",") {
+			players = append(players, game.Player{Name: name})
+		}
+		g, err = c.NewGame(ctx, rs, players)
 		if err != nil {
 			return err
 		}
@@ -79,9 +88,14 @@ func consoleLocalMultiplayer(conf *Config, args []string) error {
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
 
-	var ruleset string
+	var ruleset, bot, uciPath string
+	var botConfig engine.Config
 	local1v1Settings := flag.NewFlagSet("consoleClient", flag.ContinueOnError)
 	local1v1Settings.StringVar(&ruleset, "ruleset", "Chesseract", "Rule set to use for new games")
+	local1v1Settings.StringVar(&bot, "bot", "", "Let the computer play this colour (white or black)")
+	local1v1Settings.IntVar(&botConfig.Depth, "depth", 3, "Number of plies the computer looks ahead")
+	local1v1Settings.DurationVar(&botConfig.MoveTime, "movetime", 10*time.Second, "Maximum time the computer spends on a move")
+	local1v1Settings.StringVar(&uciPath, "uci", "", "Path to a UCI engine that plays for the computer (Boring2D only)")
 	err := local1v1Settings.Parse(args)
 	if err != nil {
 		return err
@@ -97,25 +111,55 @@ func consoleLocalMultiplayer(conf *Config, args []string) error {
 		{Name: "black"},
 	}
 
+	if bot != "" && bot != chesseract.WHITE.String() && bot != chesseract.BLACK.String() {
+		return fmt.Errorf("unknown colour '%s'", bot)
+	}
+
+	var finder engine.MoveFinder = engine.New(botConfig)
+	if uciPath != "" {
+		if bot == "" {
+			return fmt.Errorf("use -bot to pick the colour the UCI engine plays")
+		}
+		if _, ok := rs.(chesseract.Boring2D); !ok {
+			return uci.ErrUnsupportedRuleSet
+		}
+		ue, err := uci.Start(ctx, botConfig, uciPath)
+		if err != nil {
+			return err
+		}
+		defer ue.Close()
+		fmt.Printf("Playing against %s\n", ue.Name)
+		finder = ue
+	}
+
 	errs := make(chan error, 4)
 
 	s := New1v1()
 
-	var run = func(c client.Client) {
+	var run = func(c client.Client, colour chesseract.Colour) {
+		if bot == colour.String() {
+			c = engine.NewBot(c, finder)
+		}
+
 		g, err := c.NewGame(ctx, rs, players)
 		if err != nil {
 			errs <- err
 			return
 		}
 
+		if b, ok := g.(*engine.Session); ok {
+			errs <- b.Play(ctx)
+			return
+		}
+
 		cc := consoleClient{
 			Session: g,
 		}
 		errs <- cc.Run(ctx)
 	}
 
-	go run(s.B)
-	go run(s.W)
+	go run(s.B, chesseract.BLACK)
+	go run(s.W, chesseract.WHITE)
 
 	var rv error
 
@@ -146,7 +190,7 @@ func (cc consoleClient) Run(ctx context.Context) error {
 	playingAs := cc.Session.PlayingAs()
 	g := cc.Session.Game()
 	for ctx.Err() == nil {
-		for g.Match.Board.Turn != playingAs {
+		for g.Match.Board.Turn != playingAs && g.Match.Result() == nil {
 			_, err := cc.Session.NextMove(ctx)
 			if err != nil {
 				return err
@@ -155,6 +199,14 @@ func (cc consoleClient) Run(ctx context.Context) error {
 
 		consoleMutex.Lock()
 		g.Match.DebugDump(os.Stdout, nil)
+		st := g.Match.Status()
+		if res := g.Match.Result(); res != nil {
+			fmt.Printf("Game over: %s. Final score: %v\n", st, res)
+			consoleMutex.Unlock()
+			return nil
+		} else if st == chesseract.CHECK {
+			fmt.Printf("%s is in check\n", playingAs)
+		}
 		consoleMutex.Unlock()
 
 		var move chesseract.Move
@@ -163,16 +215,41 @@ func (cc consoleClient) Run(ctx context.Context) error {
 			consoleMutex.Lock()
 			fmt.Printf("Enter move for %6s: ", playingAs)
 
-			var sFrom, sTo string
-			n, _ := fmt.Scanf("%s %s\n", &sFrom, &sTo)
+			line, _ := stdin.ReadString('\n')
 			consoleMutex.Unlock()
+			fields := strings.Fields(line)
+			n := len(fields)
 			if n == 0 {
 				continue
 			}
 			if n == 1 {
-				if sFrom == "forfeit" || sFrom == "quit" {
+				if fields[0] == "forfeit" || fields[0] == "quit" {
 					return fmt.Errorf("forfeiting is not implemented")
 				}
+
+				// A single move in algebraic notation
+				var err error
+				move, err = chesseract.ParseSAN(g.Match.RuleSet, g.Match.Board, fields[0])
+				if err != nil {
+					fmt.Printf("%v\n", err)
+					continue
+				}
+				if _, err = g.Match.RuleSet.ApplyMove(g.Match.Board, move); err != nil {
+					fmt.Printf("applying move '%s': %v\n", fields[0], err)
+					continue
+				}
+				break
+			}
+			sFrom, sTo := fields[0], fields[1]
+
+			var promotion chesseract.PieceType
+			if n > 2 {
+				var err error
+				promotion, err = chesseract.ParsePieceType(fields[2])
+				if err != nil {
+					fmt.Printf("error parsing promotion '%s': %v\n", fields[2], err)
+					continue
+				}
 			}
 
 			from, err := g.Match.RuleSet.ParsePosition(sFrom)
@@ -191,6 +268,7 @@ func (cc consoleClient) Run(ctx context.Context) error {
 				PieceType: piece.PieceType,
 				From:      from,
 				To:        to,
+				Promotion: promotion,
 			}
 			_, err = g.Match.RuleSet.ApplyMove(g.Match.Board, move)
 			if err != nil {
@@ -225,7 +303,7 @@ func (cc consoleClient) Run(ctx context.Context) error {
 				return mv.Err
 				// TODO: Maybe the server just thinks this is illegal, and we should keep trying?
 			}
-			if !mv.Move.From.Equals(move.From) || !mv.Move.To.Equals(move.To) {
+			if !mv.Move.SameSquares(move) {
 				return client.ErrShenanigans
 			}
 
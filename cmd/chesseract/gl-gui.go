@@ -205,7 +205,7 @@ func (cc glClient) Run(ctx context.Context) error {
 				return mv.Err
 				// TODO: Maybe the server just thinks this is illegal, and we should keep trying?
 			}
-			if !mv.Move.From.Equals(move.From) || !mv.Move.To.Equals(move.To) {
+			if !mv.Move.SameSquares(move) {
 				return client.ErrShenanigans
 			}
 
@@ -287,11 +287,18 @@ func resolveModel(piece chesseract.Piece) (modelName string, tileIdx int, ok boo
 		chesseract.BISHOP: "bishop",
 		chesseract.QUEEN:  "queen",
 		chesseract.KING:   "king",
+
+		// There's no unicorn model yet
+		chesseract.UNICORN: "knight",
 	}
 
 	idxMap := map[chesseract.Colour]int{
 		chesseract.WHITE: 0,
 		chesseract.BLACK: 1,
+
+		// There are no textures for the four-player colours yet
+		chesseract.RED:  0,
+		chesseract.BLUE: 1,
 	}
 
 	modelName, ok = modelNames[piece.PieceType]
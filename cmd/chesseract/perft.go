@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/thijzert/chesseract/chesseract"
+)
+
+func perftCommand(conf *Config, args []string) error {
+	var ruleSetName string
+	var depth int
+	var fen string
+	var divide bool
+
+	fs := flag.NewFlagSet(os.Args[0]+" perft", flag.ContinueOnError)
+	fs.StringVar(&ruleSetName, "ruleset", "Boring2D", "Rule set to use")
+	fs.IntVar(&depth, "depth", 3, "Search depth")
+	fs.StringVar(&fen, "fen", "", "Starting position in FEN (or Hyper-FEN for Chesseract)")
+	fs.BoolVar(&divide, "divide", false, "Show the node count below each move")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	rs := chesseract.GetRuleSet(ruleSetName)
+	if rs == nil {
+		return fmt.Errorf("unknown rule set '%s'", ruleSetName)
+	}
+
+	board := rs.DefaultBoard()
+	if fen != "" {
+		board, err = chesseract.ParseBoard(rs, fen)
+		if err != nil {
+			return err
+		}
+	}
+
+	t0 := time.Now()
+	total := 0
+
+	if divide && depth > 0 {
+		for _, move := range chesseract.LegalMoves(rs, board) {
+			newBoard, err := rs.ApplyMove(board, move)
+			if err != nil {
+				return err
+			}
+			n := chesseract.Perft(rs, newBoard, depth-1)
+			total += n
+
+			var s string
+			if move.IsDrop() {
+				s = move.PieceType.Letter() + "@" + move.To.String()
+			} else {
+				s = move.From.String() + move.To.String()
+			}
+			if move.Promotion != 0 {
+				s += strings.ToLower(move.Promotion.Letter())
+			}
+			fmt.Printf("%s: %d\n", s, n)
+		}
+		fmt.Println()
+	} else {
+		total = chesseract.Perft(rs, board, depth)
+	}
+
+	dur := time.Since(t0)
+	fmt.Printf("Nodes searched: %d\n", total)
+	fmt.Printf("Time: %s (%.0f nodes/s)\n", dur.Truncate(time.Millisecond), float64(total)/dur.Seconds())
+
+	return nil
+}
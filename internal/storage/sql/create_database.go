@@ -31,6 +31,7 @@ func (d *SQLBackend) Initialise(ctx context.Context) error {
 			RuleSet    CHAR(15)     CHARSET UTF8MB4 NOT NULL DEFAULT '',
 			StartTime  DATETIME                     NOT NULL,
 			Finalised  TINYINT(1)                   NOT NULL DEFAULT 0,
+			StartingPosition TEXT       CHARSET ASCII   NOT NULL,
 			PRIMARY KEY ( MatchID )
 		) ENGINE=InnoDB
 	`)
@@ -59,6 +60,8 @@ func (d *SQLBackend) Initialise(ctx context.Context) error {
 			Ordinal    INT                          NOT NULL,
 			From_      CHAR(8)      CHARSET UTF8MB4 NOT NULL DEFAULT '',
 			To_        CHAR(8)      CHARSET UTF8MB4 NOT NULL DEFAULT '',
+			Promotion  INT                          NOT NULL DEFAULT 0,
+			Drop_      INT                          NOT NULL DEFAULT 0,
 			Time_      DECIMAL(9,3)                 NOT NULL DEFAULT 0.000,
 			PRIMARY KEY ( MatchID, Ordinal ),
 			FOREIGN KEY ( MatchID ) REFERENCES Match_(MatchID) ON UPDATE CASCADE ON DELETE RESTRICT
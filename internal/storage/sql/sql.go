@@ -242,7 +242,7 @@ func (d *SQLBackend) CheckNonce(ctx context.Context, id storage.PlayerID, nonce
 func (d *SQLBackend) NewGame(ctx context.Context) (storage.GameID, game.Game, error) {
 	g := game.Game{}
 	gid := storage.NewGameID()
-	_, err := d.conn.ExecContext(ctx, `INSERT INTO Match_ ( MatchID, StartTime ) VALUES ( ?, NOW() )`, gid.String())
+	_, err := d.conn.ExecContext(ctx, `INSERT INTO Match_ ( MatchID, StartTime, StartingPosition ) VALUES ( ?, NOW(), '' )`, gid.String())
 	if err != nil {
 		return storage.GameID{}, g, err
 	}
@@ -254,9 +254,11 @@ func (d *SQLBackend) GetGame(ctx context.Context, id storage.GameID) (game.Game,
 	rv := game.Game{}
 
 	var ruleSet string
+	var finalised bool
+	var startingPosition string
 	err := d.conn.QueryRowContext(ctx, `
-		SELECT RuleSet, StartTime FROM Match_ WHERE MatchID = ?
-	`, id.String()).Scan(&ruleSet, &rv.Match.StartTime)
+		SELECT RuleSet, StartTime, Finalised, StartingPosition FROM Match_ WHERE MatchID = ?
+	`, id.String()).Scan(&ruleSet, &rv.Match.StartTime, &finalised, &startingPosition)
 	if err == sql.ErrNoRows {
 		return rv, err
 	} else if err != nil {
@@ -265,20 +267,29 @@ func (d *SQLBackend) GetGame(ctx context.Context, id storage.GameID) (game.Game,
 
 	rv.Match.RuleSet = chesseract.GetRuleSet(ruleSet)
 
+	if startingPosition != "" {
+		board, err := chesseract.ParseBoard(rv.Match.RuleSet, startingPosition)
+		if err != nil {
+			return rv, err
+		}
+		rv.Match.StartingBoard = &board
+	}
+
 	// Get Players
 	roles := rv.Match.RuleSet.PlayerColours()
-	rows, err := d.conn.QueryContext(ctx, `SELECT PlayerID, Role FROM MatchRole WHERE MatchID = ?`, id.String())
+	rows, err := d.conn.QueryContext(ctx, `SELECT PlayerID, Role, Result FROM MatchRole WHERE MatchID = ?`, id.String())
 	if err != nil {
 		return rv, err
 	}
 	type playerRole struct {
 		PlayerID  string
 		PlayingAs chesseract.Colour
+		Result    float64
 	}
 	playerIDs := make([]playerRole, 0, len(roles))
 	for rows.Next() {
 		var pr playerRole
-		if err = rows.Scan(&pr.PlayerID, &pr.PlayingAs); err != nil {
+		if err = rows.Scan(&pr.PlayerID, &pr.PlayingAs, &pr.Result); err != nil {
 			return rv, err
 		}
 		playerIDs = append(playerIDs, pr)
@@ -288,9 +299,15 @@ func (d *SQLBackend) GetGame(ctx context.Context, id storage.GameID) (game.Game,
 		return rv, err
 	}
 
-	for _, c := range roles {
+	if finalised {
+		rv.Result = make([]float64, len(roles))
+	}
+	for j, c := range roles {
 		for i, pr := range playerIDs {
 			if pr.PlayingAs == c {
+				if finalised {
+					rv.Result[j] = pr.Result
+				}
 				pid, err := storage.ParsePlayerID(pr.PlayerID)
 				if err != nil {
 					return rv, err
@@ -309,19 +326,16 @@ func (d *SQLBackend) GetGame(ctx context.Context, id storage.GameID) (game.Game,
 	}
 
 	// Load moves
-	rv.Match.Board = rv.Match.RuleSet.DefaultBoard()
-	rows, err = d.conn.QueryContext(ctx, `SELECT From_, To_, Time_ FROM Move WHERE MatchID = ? ORDER BY Ordinal`, id.String())
+	rv.Match.Board = rv.Match.InitialBoard()
+	rows, err = d.conn.QueryContext(ctx, `SELECT From_, To_, Promotion, Drop_, Time_ FROM Move WHERE MatchID = ? ORDER BY Ordinal`, id.String())
 	if err != nil {
 		return rv, err
 	}
 	for rows.Next() {
 		var sFrom, sTo string
+		var promotion, drop chesseract.PieceType
 		var seconds float64
-		err = rows.Scan(&sFrom, &sTo, &seconds)
-		if err != nil {
-			return rv, err
-		}
-		p, err := rv.Match.RuleSet.ParsePosition(sFrom)
+		err = rows.Scan(&sFrom, &sTo, &promotion, &drop, &seconds)
 		if err != nil {
 			return rv, err
 		}
@@ -330,12 +344,21 @@ func (d *SQLBackend) GetGame(ctx context.Context, id storage.GameID) (game.Game,
 			return rv, err
 		}
 		mv := chesseract.Move{
-			From: p,
-			To:   q,
-			Time: time.Duration(int64(1000000.0*seconds) * int64(time.Microsecond)),
+			To:        q,
+			Promotion: promotion,
+			Time:      time.Duration(int64(1000000.0*seconds) * int64(time.Microsecond)),
 		}
-		if pt, ok := rv.Match.Board.At(mv.From); ok {
-			mv.PieceType = pt.PieceType
+		if sFrom == "" && drop != 0 {
+			// Drops have no origin, so the piece type is stored separately
+			mv.PieceType = drop
+		} else {
+			mv.From, err = rv.Match.RuleSet.ParsePosition(sFrom)
+			if err != nil {
+				return rv, err
+			}
+			if pt, ok := rv.Match.Board.At(mv.From); ok {
+				mv.PieceType = pt.PieceType
+			}
 		}
 
 		newb, err := rv.Match.RuleSet.ApplyMove(rv.Match.Board, mv)
@@ -369,13 +392,22 @@ func (d *SQLBackend) StoreGame(ctx context.Context, id storage.GameID, match gam
 			finalised = true
 		}
 	}
+	startingPosition := ""
+	if match.Match.StartingBoard != nil {
+		startingPosition, err = chesseract.FormatBoard(match.Match.RuleSet, *match.Match.StartingBoard)
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err = d.conn.ExecContext(ctx, `
 		UPDATE Match_
 		SET RuleSet = ?,
 			StartTime = ?,
-			Finalised = ?
+			Finalised = ?,
+			StartingPosition = ?
 		WHERE MatchID = ?
-	`, match.Match.RuleSet.String(), match.Match.StartTime, finalised, id.String())
+	`, match.Match.RuleSet.String(), match.Match.StartTime, finalised, startingPosition, id.String())
 	if err != nil {
 		return err
 	}
@@ -421,10 +453,16 @@ func (d *SQLBackend) StoreGame(ctx context.Context, id storage.GameID, match gam
 		return err
 	}
 	for i, mv := range match.Match.Moves {
+		from, drop := "", chesseract.PieceType(0)
+		if mv.IsDrop() {
+			drop = mv.PieceType
+		} else {
+			from = mv.From.String()
+		}
 		_, err := d.conn.ExecContext(ctx, `
-			INSERT INTO Move ( MatchID, Ordinal, From_, To_, Time_ )
-			VALUES ( ?, ?, ?, ?, ? )
-		`, id.String(), i+1, mv.From.String(), mv.To.String(), mv.Time.Seconds())
+			INSERT INTO Move ( MatchID, Ordinal, From_, To_, Promotion, Drop_, Time_ )
+			VALUES ( ?, ?, ?, ?, ?, ?, ? )
+		`, id.String(), i+1, from, mv.To.String(), mv.Promotion, drop, mv.Time.Seconds())
 		if err != nil {
 			return err
 		}
@@ -262,12 +262,13 @@ func (w webProvider) GetGame(gameid string) (*game.Game, error) {
 	if err != nil {
 		return nil, err
 	}
+	g = w.view(g)
 
 	return &g, nil
 }
 
 // NewGame creates a new game with the specified players, and returns its game ID
-func (w webProvider) NewGame(ruleset string, playerNames []string) (string, error) {
+func (w webProvider) NewGame(ruleset string, playerNames []string, startingPosition string) (string, error) {
 	sess, err := w.Server.storage.GetSession(w.Context, w.SessionID)
 	if err != nil {
 		return "", err
@@ -289,6 +290,15 @@ func (w webProvider) NewGame(ruleset string, playerNames []string) (string, erro
 		return "", weberrors.WithStatus(errors.New("incorrect number of players for this rule set"), 400)
 	}
 
+	var startingBoard *chesseract.Board
+	if startingPosition != "" {
+		board, err := chesseract.ParseBoard(rs, startingPosition)
+		if err != nil {
+			return "", weberrors.WithStatus(err, 400)
+		}
+		startingBoard = &board
+	}
+
 	for i, playerName := range playerNames {
 		id, ok, err := w.Server.storage.LookupPlayer(w.Context, playerName)
 		if err != nil {
@@ -325,7 +335,8 @@ func (w webProvider) NewGame(ruleset string, playerNames []string) (string, erro
 	}
 
 	g.Match.StartTime = time.Now()
-	g.Match.Board = g.Match.RuleSet.DefaultBoard()
+	g.Match.StartingBoard = startingBoard
+	g.Match.Board = g.Match.InitialBoard()
 
 	err = w.Server.storage.StoreGame(w.Context, id, g)
 	if err != nil {
@@ -335,15 +346,39 @@ func (w webProvider) NewGame(ruleset string, playerNames []string) (string, erro
 	return id.String(), nil
 }
 
+// Game returns the currently active game, as seen by the player of this
+// session
 func (w webProvider) Game() (*game.Game, error) {
 	rv, err := w.Server.storage.GetGame(w.Context, w.GameID)
 	if err != nil {
 		return nil, err
 	}
+	rv = w.view(rv)
 
 	return &rv, nil
 }
 
+// view hides the parts of a game that the player of this session can't see.
+// Once the game is over, everything is revealed.
+func (w webProvider) view(g game.Game) game.Game {
+	if _, ok := g.Match.RuleSet.(chesseract.FogOfWar); !ok || g.Result != nil {
+		return g
+	}
+
+	// Players that aren't part of the game don't get to see anything
+	var colours []chesseract.Colour
+	if player, err := w.Player(); err == nil {
+		for _, pl := range g.Players {
+			if pl.Name == player.Name {
+				colours = append(colours, pl.PlayingAs)
+			}
+		}
+	}
+
+	g.Match = g.Match.View(colours...)
+	return g
+}
+
 func (w webProvider) SubmitMove(mov chesseract.Move) error {
 	return w.Server.storage.Transaction(w.Context, func(ctx context.Context) error {
 		g, err := w.Server.storage.GetGame(ctx, w.GameID)
@@ -351,6 +386,25 @@ func (w webProvider) SubmitMove(mov chesseract.Move) error {
 			return err
 		}
 
+		if g.Result != nil {
+			return client.ErrGameHasFinished
+		}
+
+		// With more than two players, it matters whose turn it is
+		player, err := w.Player()
+		if err != nil {
+			return err
+		}
+		yourTurn := false
+		for _, pl := range g.Players {
+			if pl.Name == player.Name && pl.PlayingAs == g.Match.Board.Turn {
+				yourTurn = true
+			}
+		}
+		if !yourTurn {
+			return client.ErrNotYourTurn
+		}
+
 		if piece, ok := g.Match.Board.At(mov.From); ok {
 			mov.PieceType = piece.PieceType
 		}
@@ -368,6 +422,7 @@ func (w webProvider) SubmitMove(mov chesseract.Move) error {
 
 		g.Match.Board = newb
 		g.Match.Moves = append(g.Match.Moves, mov)
+		g.Result = g.Match.Result()
 
 		return w.Server.storage.StoreGame(ctx, w.GameID, g)
 	})
@@ -11,9 +11,13 @@ var MoveHandler moveHandler
 
 type moveHandler struct{}
 
+// The MoveRequest wraps a MoveHandler API request. For a drop, From is empty
+// and Drop contains the type of the piece taken from the reserve.
 type MoveRequest struct {
-	From string `json:"from"`
-	To   string `json:"to"`
+	From      string               `json:"from"`
+	To        string               `json:"to"`
+	Promotion chesseract.PieceType `json:"promotion,omitempty"`
+	Drop      chesseract.PieceType `json:"drop,omitempty"`
 }
 
 // The MoveResponse wraps a MoveHandler API response
@@ -30,14 +34,19 @@ func (moveHandler) handleMove(p Provider, r MoveRequest) (MoveResponse, error) {
 	rs := g.Match.RuleSet
 
 	mov := chesseract.Move{}
-	mov.From, err = rs.ParsePosition(r.From)
-	if err != nil {
-		return rv, err
+	if r.From == "" && r.Drop != 0 {
+		mov.PieceType = r.Drop
+	} else {
+		mov.From, err = rs.ParsePosition(r.From)
+		if err != nil {
+			return rv, err
+		}
 	}
 	mov.To, err = rs.ParsePosition(r.To)
 	if err != nil {
 		return rv, err
 	}
+	mov.Promotion = r.Promotion
 
 	err = p.SubmitMove(mov)
 
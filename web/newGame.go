@@ -12,6 +12,10 @@ type newGameHandler struct{}
 type NewGameRequest struct {
 	RuleSet     string   `json:"ruleset"`
 	PlayerNames []string `json:"players"`
+
+	// StartingPosition optionally contains the starting position in FEN, or
+	// Hyper-FEN for 4D games
+	StartingPosition string `json:"fen,omitempty"`
 }
 
 // The NewGameResponse wraps a NewGameHandler API response
@@ -22,7 +26,7 @@ type NewGameResponse struct {
 func (newGameHandler) handleNewGame(p Provider, r NewGameRequest) (NewGameResponse, error) {
 	var rv NewGameResponse
 
-	id, err := p.NewGame(r.RuleSet, r.PlayerNames)
+	id, err := p.NewGame(r.RuleSet, r.PlayerNames, r.StartingPosition)
 	if err != nil {
 		return rv, err
 	}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/thijzert/chesseract/chesseract"
+	"github.com/thijzert/chesseract/chesseract/game"
 )
 
 var NextMoveHandler nextMoveHandler
@@ -18,6 +19,11 @@ type nextMoveRequest struct {
 // The NextMoveResponse wraps a NextMoveHandler API response
 type NextMoveResponse struct {
 	Move *chesseract.Move `json:"move,omitempty"`
+
+	// Game contains the game as the player sees it, in rule sets with a fog
+	// of war. Clients can't replay moves on a partial board, so they should
+	// use this instead. It may include moves made after this one.
+	Game *game.Game `json:"game,omitempty"`
 }
 
 func (nextMoveHandler) handleNextMove(p Provider, r nextMoveRequest) (NextMoveResponse, error) {
@@ -30,6 +36,9 @@ func (nextMoveHandler) handleNextMove(p Provider, r nextMoveRequest) (NextMoveRe
 
 	if len(g.Match.Moves) > r.NextIndex {
 		rv.Move = &g.Match.Moves[r.NextIndex]
+		if _, ok := g.Match.RuleSet.(chesseract.FogOfWar); ok {
+			rv.Game = g
+		}
 	}
 
 	// TODO: Hang around for a bit, and see if a move gets added.
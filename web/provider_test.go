@@ -50,7 +50,7 @@ func (t testProvider) GetGame(gameid string) (*game.Game, error) {
 }
 
 // NewGame creates a new game with the specified players, and returns its game ID
-func (t testProvider) NewGame(ruleset string, playerNames []string) (string, error) {
+func (t testProvider) NewGame(ruleset string, playerNames []string, startingPosition string) (string, error) {
 	return "", notimplemented.Error()
 }
 
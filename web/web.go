@@ -33,13 +33,18 @@ type Provider interface {
 	// ActiveGames returns the list of active game ID's in which the player is involved
 	ActiveGames() ([]string, error)
 
-	// GetGame retrieves a game by its ID
+	// GetGame retrieves a game by its ID. In rule sets with a fog of war,
+	// the game only contains what the player can see.
 	GetGame(gameid string) (*game.Game, error)
 
-	// NewGame creates a new game with the specified players, and returns its game ID
-	NewGame(ruleset string, playerNames []string) (string, error)
+	// NewGame creates a new game with the specified players, and returns its
+	// game ID. If the starting position is empty, the game starts with the
+	// rule set's default board.
+	NewGame(ruleset string, playerNames []string, startingPosition string) (string, error)
 
-	// Game returns the game object of the currently active game session, if applicable
+	// Game returns the game object of the currently active game session, if
+	// applicable. In rule sets with a fog of war, the game only contains what
+	// the player can see.
 	Game() (*game.Game, error)
 
 	// SubmitMove appends a move to the currently active game